@@ -19,7 +19,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/containerinstance/mgmt/2018-10-01/containerinstance"
 	"github.com/Azure/azure-sdk-for-go/services/containerregistry/mgmt/2017-10-01/containerregistry"
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2018-03-31/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2019-02-01/containerservice"
 	"github.com/Azure/azure-sdk-for-go/services/cosmos-db/mgmt/2015-04-08/documentdb"
 	"github.com/Azure/azure-sdk-for-go/services/databricks/mgmt/2018-04-01/databricks"
 	analyticsAccount "github.com/Azure/azure-sdk-for-go/services/datalake/analytics/mgmt/2016-11-01/account"
@@ -51,6 +51,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/preview/signalr/mgmt/2018-03-01-preview/signalr"
 	"github.com/Azure/azure-sdk-for-go/services/preview/sql/mgmt/2015-05-01-preview/sql"
 	MsSql "github.com/Azure/azure-sdk-for-go/services/preview/sql/mgmt/2017-10-01-preview/sql"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/Azure/azure-sdk-for-go/services/recoveryservices/mgmt/2016-06-01/recoveryservices"
 	"github.com/Azure/azure-sdk-for-go/services/recoveryservices/mgmt/2017-07-01/backup"
 	"github.com/Azure/azure-sdk-for-go/services/redis/mgmt/2018-03-01/redis"
@@ -82,13 +83,15 @@ import (
 // ArmClient contains the handles to all the specific Azure Resource Manager
 // resource classes' respective clients.
 type ArmClient struct {
-	clientId                 string
-	tenantId                 string
-	subscriptionId           string
-	partnerId                string
-	usingServicePrincipal    bool
-	environment              az.Environment
-	skipProviderRegistration bool
+	clientId                    string
+	tenantId                    string
+	subscriptionId              string
+	partnerId                   string
+	usingServicePrincipal       bool
+	usingClientCertificate      bool
+	usingManagedServiceIdentity bool
+	environment                 az.Environment
+	skipProviderRegistration    bool
 
 	StopContext context.Context
 
@@ -107,10 +110,15 @@ type ArmClient struct {
 	dnsClient   dns.RecordSetsClient
 	zonesClient dns.ZonesClient
 
+	privateDnsClient                    privatedns.RecordSetsClient
+	privateDnsZonesClient               privatedns.PrivateZonesClient
+	privateDnsVirtualNetworkLinksClient privatedns.VirtualNetworkLinksClient
+
 	containerRegistryClient             containerregistry.RegistriesClient
 	containerRegistryReplicationsClient containerregistry.ReplicationsClient
 	containerServicesClient             containerservice.ContainerServicesClient
 	kubernetesClustersClient            containerservice.ManagedClustersClient
+	kubernetesNodePoolsClient           containerservice.AgentPoolsClient
 	containerGroupsClient               containerinstance.ContainerGroupsClient
 
 	eventGridDomainsClient            eventgrid.DomainsClient
@@ -265,29 +273,38 @@ type ArmClient struct {
 	userAssignedIdentitiesClient msi.UserAssignedIdentitiesClient
 
 	// Networking
-	applicationGatewayClient        network.ApplicationGatewaysClient
-	applicationSecurityGroupsClient network.ApplicationSecurityGroupsClient
-	azureFirewallsClient            network.AzureFirewallsClient
-	connectionMonitorsClient        network.ConnectionMonitorsClient
-	ddosProtectionPlanClient        network.DdosProtectionPlansClient
-	expressRouteAuthsClient         network.ExpressRouteCircuitAuthorizationsClient
-	expressRouteCircuitClient       network.ExpressRouteCircuitsClient
-	expressRoutePeeringsClient      network.ExpressRouteCircuitPeeringsClient
-	ifaceClient                     network.InterfacesClient
-	loadBalancerClient              network.LoadBalancersClient
-	localNetConnClient              network.LocalNetworkGatewaysClient
-	packetCapturesClient            network.PacketCapturesClient
-	publicIPClient                  network.PublicIPAddressesClient
-	routesClient                    network.RoutesClient
-	routeTablesClient               network.RouteTablesClient
-	secGroupClient                  network.SecurityGroupsClient
-	secRuleClient                   network.SecurityRulesClient
-	subnetClient                    network.SubnetsClient
-	vnetGatewayConnectionsClient    network.VirtualNetworkGatewayConnectionsClient
-	vnetGatewayClient               network.VirtualNetworkGatewaysClient
-	vnetClient                      network.VirtualNetworksClient
-	vnetPeeringsClient              network.VirtualNetworkPeeringsClient
-	watcherClient                   network.WatchersClient
+	applicationGatewayClient             network.ApplicationGatewaysClient
+	applicationSecurityGroupsClient      network.ApplicationSecurityGroupsClient
+	azureFirewallsClient                 network.AzureFirewallsClient
+	connectionMonitorsClient             network.ConnectionMonitorsClient
+	ddosProtectionPlanClient             network.DdosProtectionPlansClient
+	expressRouteAuthsClient              network.ExpressRouteCircuitAuthorizationsClient
+	expressRouteCircuitClient            network.ExpressRouteCircuitsClient
+	expressRoutePeeringsClient           network.ExpressRouteCircuitPeeringsClient
+	expressRoutePortsClient              network.ExpressRoutePortsClient
+	ifaceClient                          network.InterfacesClient
+	loadBalancerClient                   network.LoadBalancersClient
+	loadBalancerBatcher                  *loadBalancerBatcher
+	localNetConnClient                   network.LocalNetworkGatewaysClient
+	packetCapturesClient                 network.PacketCapturesClient
+	publicIPClient                       network.PublicIPAddressesClient
+	publicIPPrefixClient                 network.PublicIPPrefixesClient
+	routeFiltersClient                   network.RouteFiltersClient
+	routesClient                         network.RoutesClient
+	routeTablesClient                    network.RouteTablesClient
+	secGroupClient                       network.SecurityGroupsClient
+	secRuleClient                        network.SecurityRulesClient
+	subnetClient                         network.SubnetsClient
+	vnetGatewayConnectionsClient         network.VirtualNetworkGatewayConnectionsClient
+	vnetGatewayClient                    network.VirtualNetworkGatewaysClient
+	vnetClient                           network.VirtualNetworksClient
+	vnetPeeringsClient                   network.VirtualNetworkPeeringsClient
+	virtualHubClient                     network.VirtualHubsClient
+	virtualWanClient                     network.VirtualWansClient
+	vpnGatewayClient                     network.VpnGatewaysClient
+	vpnSiteClient                        network.VpnSitesClient
+	watcherClient                        network.WatchersClient
+	webApplicationFirewallPoliciesClient network.WebApplicationFirewallPoliciesClient
 
 	// Notification Hubs
 	notificationHubsClient       notificationhubs.Client
@@ -488,6 +505,7 @@ func getArmClient(c *authentication.Config, skipProviderRegistration bool, partn
 	client.registerOperationalInsightsClients(endpoint, c.SubscriptionID, auth)
 	client.registerRecoveryServiceClients(endpoint, c.SubscriptionID, auth)
 	client.registerPolicyClients(endpoint, c.SubscriptionID, auth)
+	client.registerPrivateDNSClients(endpoint, c.SubscriptionID, auth)
 	client.registerManagementGroupClients(endpoint, auth)
 	client.registerRedisClients(endpoint, c.SubscriptionID, auth)
 	client.registerRelayClients(endpoint, c.SubscriptionID, auth)
@@ -772,6 +790,10 @@ func (c *ArmClient) registerContainerServicesClients(endpoint, subscriptionId st
 	kubernetesClustersClient := containerservice.NewManagedClustersClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&kubernetesClustersClient.Client, auth)
 	c.kubernetesClustersClient = kubernetesClustersClient
+
+	kubernetesNodePoolsClient := containerservice.NewAgentPoolsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&kubernetesNodePoolsClient.Client, auth)
+	c.kubernetesNodePoolsClient = kubernetesNodePoolsClient
 }
 
 func (c *ArmClient) registerDatabricksClients(endpoint, subscriptionId string, auth autorest.Authorizer) {
@@ -1041,6 +1063,10 @@ func (c *ArmClient) registerNetworkingClients(endpoint, subscriptionId string, a
 	c.configureClient(&expressRoutePeeringsClient.Client, auth)
 	c.expressRoutePeeringsClient = expressRoutePeeringsClient
 
+	expressRoutePortsClient := network.NewExpressRoutePortsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&expressRoutePortsClient.Client, auth)
+	c.expressRoutePortsClient = expressRoutePortsClient
+
 	interfacesClient := network.NewInterfacesClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&interfacesClient.Client, auth)
 	c.ifaceClient = interfacesClient
@@ -1048,6 +1074,7 @@ func (c *ArmClient) registerNetworkingClients(endpoint, subscriptionId string, a
 	loadBalancersClient := network.NewLoadBalancersClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&loadBalancersClient.Client, auth)
 	c.loadBalancerClient = loadBalancersClient
+	c.loadBalancerBatcher = newLoadBalancerBatcher(armLoadBalancerUpdater{client: loadBalancersClient}, loadBalancerBatchWindow)
 
 	localNetworkGatewaysClient := network.NewLocalNetworkGatewaysClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&localNetworkGatewaysClient.Client, auth)
@@ -1073,10 +1100,34 @@ func (c *ArmClient) registerNetworkingClients(endpoint, subscriptionId string, a
 	c.configureClient(&peeringsClient.Client, auth)
 	c.vnetPeeringsClient = peeringsClient
 
+	virtualHubsClient := network.NewVirtualHubsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&virtualHubsClient.Client, auth)
+	c.virtualHubClient = virtualHubsClient
+
+	virtualWansClient := network.NewVirtualWansClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&virtualWansClient.Client, auth)
+	c.virtualWanClient = virtualWansClient
+
+	vpnGatewaysClient := network.NewVpnGatewaysClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&vpnGatewaysClient.Client, auth)
+	c.vpnGatewayClient = vpnGatewaysClient
+
+	vpnSitesClient := network.NewVpnSitesClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&vpnSitesClient.Client, auth)
+	c.vpnSiteClient = vpnSitesClient
+
 	publicIPAddressesClient := network.NewPublicIPAddressesClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&publicIPAddressesClient.Client, auth)
 	c.publicIPClient = publicIPAddressesClient
 
+	publicIPPrefixesClient := network.NewPublicIPPrefixesClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&publicIPPrefixesClient.Client, auth)
+	c.publicIPPrefixClient = publicIPPrefixesClient
+
+	routeFiltersClient := network.NewRouteFiltersClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&routeFiltersClient.Client, auth)
+	c.routeFiltersClient = routeFiltersClient
+
 	routesClient := network.NewRoutesClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&routesClient.Client, auth)
 	c.routesClient = routesClient
@@ -1104,6 +1155,10 @@ func (c *ArmClient) registerNetworkingClients(endpoint, subscriptionId string, a
 	watchersClient := network.NewWatchersClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&watchersClient.Client, auth)
 	c.watcherClient = watchersClient
+
+	webApplicationFirewallPoliciesClient := network.NewWebApplicationFirewallPoliciesClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&webApplicationFirewallPoliciesClient.Client, auth)
+	c.webApplicationFirewallPoliciesClient = webApplicationFirewallPoliciesClient
 }
 
 func (c *ArmClient) registerNotificationHubsClient(endpoint, subscriptionId string, auth autorest.Authorizer) {
@@ -1295,6 +1350,20 @@ func (c *ArmClient) registerWebClients(endpoint, subscriptionId string, auth aut
 	c.appServicesClient = appsClient
 }
 
+func (c *ArmClient) registerPrivateDNSClients(endpoint, subscriptionId string, auth autorest.Authorizer) {
+	rs := privatedns.NewRecordSetsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&rs.Client, auth)
+	c.privateDnsClient = rs
+
+	zo := privatedns.NewPrivateZonesClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&zo.Client, auth)
+	c.privateDnsZonesClient = zo
+
+	vnl := privatedns.NewVirtualNetworkLinksClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&vnl.Client, auth)
+	c.privateDnsVirtualNetworkLinksClient = vnl
+}
+
 func (c *ArmClient) registerPolicyClients(endpoint, subscriptionId string, auth autorest.Authorizer) {
 	policyAssignmentsClient := policy.NewAssignmentsClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&policyAssignmentsClient.Client, auth)
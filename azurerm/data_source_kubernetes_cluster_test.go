@@ -36,6 +36,7 @@ func TestAccDataSourceAzureRMKubernetesCluster_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(dataSourceName, "kube_config.0.password"),
 					resource.TestCheckResourceAttr(dataSourceName, "kube_admin_config.#", "0"),
 					resource.TestCheckResourceAttr(dataSourceName, "kube_admin_config_raw", ""),
+					resource.TestCheckResourceAttr(dataSourceName, "kube_config_exec.#", "0"),
 				),
 			},
 		},
@@ -94,6 +95,9 @@ func TestAccDataSourceAzureRMKubernetesCluster_roleBasedAccessControlAAD(t *test
 					resource.TestCheckResourceAttrSet(dataSourceName, "role_based_access_control.0.azure_active_directory.0.tenant_id"),
 					resource.TestCheckResourceAttr(dataSourceName, "kube_admin_config.#", "1"),
 					resource.TestCheckResourceAttrSet(dataSourceName, "kube_admin_config_raw"),
+					resource.TestCheckResourceAttr(dataSourceName, "kube_config_exec.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "kube_config_exec.0.command", "kubelogin"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "kube_config_exec_raw"),
 				),
 			},
 		},
@@ -3,6 +3,7 @@ package azurerm
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -65,6 +66,13 @@ func dataSourceArmSharedImageVersion() *schema.Resource {
 				Computed: true,
 			},
 
+			"end_of_life_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"replication_status": sharedImageVersionReplicationStatusSchema(),
+
 			"tags": tagsForDataSourceSchema(),
 		},
 	}
@@ -101,9 +109,17 @@ func dataSourceArmSharedImageVersionRead(d *schema.ResourceData, meta interface{
 	}
 
 	if props := resp.GalleryImageVersionProperties; props != nil {
+		if err := d.Set("replication_status", flattenSharedImageVersionReplicationStatus(props.ReplicationStatus)); err != nil {
+			return fmt.Errorf("Error setting `replication_status`: %+v", err)
+		}
+
 		if profile := props.PublishingProfile; profile != nil {
 			d.Set("exclude_from_latest", profile.ExcludeFromLatest)
 
+			if endOfLifeDate := profile.EndOfLifeDate; endOfLifeDate != nil {
+				d.Set("end_of_life_date", endOfLifeDate.Format(time.RFC3339))
+			}
+
 			flattenedRegions := flattenSharedImageVersionDataSourceTargetRegions(profile.TargetRegions)
 			if err := d.Set("target_region", flattenedRegions); err != nil {
 				return fmt.Errorf("Error setting `target_region`: %+v", err)
@@ -0,0 +1,276 @@
+package azurerm
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmVirtualNetworkGatewayBgpStatus() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmVirtualNetworkGatewayBgpStatusRead,
+
+		Schema: map[string]*schema.Schema{
+			"virtual_network_gateway_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"peer": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.IPv4Address,
+			},
+
+			"bgp_peer": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"local_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"neighbor": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"asn": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"connected_duration": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"routes_received": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"messages_sent": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"messages_received": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"learned_route": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"local_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"network": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"next_hop": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"source_peer": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"origin": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"as_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"weight": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmVirtualNetworkGatewayBgpStatusRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vnetGatewayClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("virtual_network_gateway_name").(string)
+	resGroup := d.Get("resource_group_name").(string)
+	peer := d.Get("peer").(string)
+
+	resp, err := client.Get(ctx, resGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("Virtual Network Gateway %q (Resource Group %q) was not found", name, resGroup)
+		}
+
+		return fmt.Errorf("Error making Read request on AzureRM Virtual Network Gateway %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	if props := resp.VirtualNetworkGatewayPropertiesFormat; props == nil || props.EnableBgp == nil || !*props.EnableBgp {
+		return fmt.Errorf("BGP is not enabled on Virtual Network Gateway %q (Resource Group %q)", name, resGroup)
+	}
+
+	peersFuture, err := client.GetBgpPeerStatus(ctx, resGroup, name, peer)
+	if err != nil {
+		return fmt.Errorf("Error retrieving BGP Peer Status for Virtual Network Gateway %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	if err = peersFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for retrieval of BGP Peer Status for Virtual Network Gateway %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	peers, err := peersFuture.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving BGP Peer Status for Virtual Network Gateway %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	routesFuture, err := client.GetLearnedRoutes(ctx, resGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Learned Routes for Virtual Network Gateway %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	if err = routesFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for retrieval of Learned Routes for Virtual Network Gateway %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	routes, err := routesFuture.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Learned Routes for Virtual Network Gateway %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	d.SetId(*resp.ID)
+
+	d.Set("virtual_network_gateway_name", resp.Name)
+	d.Set("resource_group_name", resGroup)
+
+	if err := d.Set("bgp_peer", flattenArmVirtualNetworkGatewayBgpPeerStatus(peers.Value)); err != nil {
+		return fmt.Errorf("Error setting `bgp_peer`: %+v", err)
+	}
+
+	if err := d.Set("learned_route", flattenArmVirtualNetworkGatewayLearnedRoutes(routes.Value)); err != nil {
+		return fmt.Errorf("Error setting `learned_route`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenArmVirtualNetworkGatewayBgpPeerStatus(input *[]network.BgpPeerStatus) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, status := range *input {
+		output := map[string]interface{}{
+			"state": string(status.State),
+		}
+
+		if status.LocalAddress != nil {
+			output["local_address"] = *status.LocalAddress
+		}
+
+		if status.Neighbor != nil {
+			output["neighbor"] = *status.Neighbor
+		}
+
+		if status.Asn != nil {
+			output["asn"] = int(*status.Asn)
+		}
+
+		if status.ConnectedDuration != nil {
+			output["connected_duration"] = *status.ConnectedDuration
+		}
+
+		if status.RoutesReceived != nil {
+			output["routes_received"] = int(*status.RoutesReceived)
+		}
+
+		if status.MessagesSent != nil {
+			output["messages_sent"] = int(*status.MessagesSent)
+		}
+
+		if status.MessagesReceived != nil {
+			output["messages_received"] = int(*status.MessagesReceived)
+		}
+
+		results = append(results, output)
+	}
+
+	return results
+}
+
+func flattenArmVirtualNetworkGatewayLearnedRoutes(input *[]network.GatewayRoute) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, route := range *input {
+		output := make(map[string]interface{})
+
+		if route.LocalAddress != nil {
+			output["local_address"] = *route.LocalAddress
+		}
+
+		if route.NetworkProperty != nil {
+			output["network"] = *route.NetworkProperty
+		}
+
+		if route.NextHop != nil {
+			output["next_hop"] = *route.NextHop
+		}
+
+		if route.SourcePeer != nil {
+			output["source_peer"] = *route.SourcePeer
+		}
+
+		if route.Origin != nil {
+			output["origin"] = *route.Origin
+		}
+
+		if route.AsPath != nil {
+			output["as_path"] = *route.AsPath
+		}
+
+		if route.Weight != nil {
+			output["weight"] = int(*route.Weight)
+		}
+
+		results = append(results, output)
+	}
+
+	return results
+}
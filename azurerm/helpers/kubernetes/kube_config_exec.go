@@ -0,0 +1,164 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// ExecCredentialAPIVersion is the version of the client.authentication.k8s.io API used by the credential plugin
+	ExecCredentialAPIVersion = "client.authentication.k8s.io/v1beta1"
+
+	// ExecCredentialCommand is the credential plugin used to retrieve Azure Active Directory tokens
+	ExecCredentialCommand = "kubelogin"
+
+	// ExecLoginModeAzureCLI retrieves the token using the credentials from the Azure CLI
+	ExecLoginModeAzureCLI = "azurecli"
+
+	// ExecLoginModeServicePrincipal retrieves the token using a Service Principal, where the Client Secret
+	// is read from the `AAD_SERVICE_PRINCIPAL_CLIENT_SECRET` Environment Variable
+	ExecLoginModeServicePrincipal = "spn"
+
+	// ExecLoginModeManagedServiceIdentity retrieves the token using the Managed Service Identity available
+	// on the machine running the credential plugin
+	ExecLoginModeManagedServiceIdentity = "msi"
+)
+
+type userItemExec struct {
+	Name string   `yaml:"name"`
+	User userExec `yaml:"user"`
+}
+
+type userExec struct {
+	Exec execConfig `yaml:"exec"`
+}
+
+type execConfig struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Command    string   `yaml:"command"`
+	Args       []string `yaml:"args"`
+}
+
+type KubeConfigExec struct {
+	KubeConfigBase `yaml:",inline"`
+	Users          []userItemExec `yaml:"users"`
+}
+
+// ExecCredentialOptions configures how the credential plugin authenticates against Azure Active Directory
+type ExecCredentialOptions struct {
+	// Environment is the name of the Azure Environment, e.g. `AzurePublicCloud`
+	Environment string
+
+	// LoginMode is the login mode used by the credential plugin, e.g. `azurecli` or `spn`
+	LoginMode string
+
+	// ClientID is the Client ID of the Service Principal used when the LoginMode is `spn`, or
+	// the (optional) Client ID of the User Assigned Identity used when the LoginMode is `msi`
+	ClientID string
+}
+
+// ExecCredentialAuthentication describes how the Provider authenticated against Azure Active Directory
+type ExecCredentialAuthentication struct {
+	Environment                 string
+	ClientID                    string
+	UsingServicePrincipal       bool
+	UsingClientCertificate      bool
+	UsingManagedServiceIdentity bool
+}
+
+// NewExecCredentialOptions returns the options for a credential plugin which authenticates in the same way
+// as the Provider - returning an error where the credential plugin can't be configured non-interactively
+func NewExecCredentialOptions(auth ExecCredentialAuthentication) (*ExecCredentialOptions, error) {
+	options := ExecCredentialOptions{
+		Environment: auth.Environment,
+	}
+
+	switch {
+	case auth.UsingClientCertificate:
+		// the credential plugin requires the Client Certificate to be available at a path on the machine using the
+		// KubeConfig, which we can't assume - so rather than generating a config which won't work we return an error
+		return nil, fmt.Errorf("Client Certificate authentication isn't supported by the %q credential plugin", ExecCredentialCommand)
+
+	case auth.UsingServicePrincipal:
+		options.LoginMode = ExecLoginModeServicePrincipal
+		options.ClientID = auth.ClientID
+
+	case auth.UsingManagedServiceIdentity:
+		options.LoginMode = ExecLoginModeManagedServiceIdentity
+		options.ClientID = auth.ClientID
+
+	default:
+		options.LoginMode = ExecLoginModeAzureCLI
+	}
+
+	return &options, nil
+}
+
+// NewKubeConfigExec converts a KubeConfig using the `azure` auth-provider into one which retrieves
+// tokens through an `exec` credential plugin, since the auth-provider isn't usable non-interactively
+func NewKubeConfigExec(config KubeConfigAAD, options ExecCredentialOptions) (*KubeConfigExec, error) {
+	if options.Environment == "" {
+		return nil, fmt.Errorf("An Environment must be specified")
+	}
+	if options.LoginMode == ExecLoginModeServicePrincipal && options.ClientID == "" {
+		return nil, fmt.Errorf("A Client ID must be specified when using the %q login mode", ExecLoginModeServicePrincipal)
+	}
+
+	users := make([]userItemExec, 0)
+	for _, item := range config.Users {
+		aad := item.User.AuthProvider.Config
+		if aad.APIServerID == "" || aad.TenantID == "" {
+			return nil, fmt.Errorf("User %q has no Azure Active Directory Server ID or Tenant ID", item.Name)
+		}
+
+		clientId := aad.ClientID
+		if options.LoginMode == ExecLoginModeServicePrincipal || options.LoginMode == ExecLoginModeManagedServiceIdentity {
+			clientId = options.ClientID
+		}
+
+		args := []string{
+			"get-token",
+			"--environment", options.Environment,
+			"--server-id", aad.APIServerID,
+		}
+		// when using a System Assigned Identity there's no Client ID to specify
+		if clientId != "" {
+			args = append(args, "--client-id", clientId)
+		}
+		args = append(args, "--tenant-id", aad.TenantID)
+		if options.LoginMode != "" {
+			args = append(args, "--login", options.LoginMode)
+		}
+
+		users = append(users, userItemExec{
+			Name: item.Name,
+			User: userExec{
+				Exec: execConfig{
+					APIVersion: ExecCredentialAPIVersion,
+					Command:    ExecCredentialCommand,
+					Args:       args,
+				},
+			},
+		})
+	}
+
+	if len(users) == 0 {
+		return nil, fmt.Errorf("Config contains no users")
+	}
+
+	return &KubeConfigExec{
+		KubeConfigBase: config.KubeConfigBase,
+		Users:          users,
+	}, nil
+}
+
+// Raw returns the KubeConfig serialized as YAML
+func (c KubeConfigExec) Raw() (string, error) {
+	out, err := yaml.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("Failed to marshal YAML config with error %+v", err)
+	}
+
+	return string(out), nil
+}
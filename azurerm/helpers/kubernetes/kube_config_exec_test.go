@@ -0,0 +1,249 @@
+package kubernetes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewKubeConfigExec(t *testing.T) {
+	testCases := []struct {
+		name         string
+		options      ExecCredentialOptions
+		expectedArgs []string
+		expectError  bool
+	}{
+		{
+			name: "Azure CLI",
+			options: ExecCredentialOptions{
+				Environment: "AzurePublicCloud",
+				LoginMode:   ExecLoginModeAzureCLI,
+			},
+			expectedArgs: []string{
+				"get-token",
+				"--environment", "AzurePublicCloud",
+				"--server-id", "test-server-id",
+				"--client-id", "test-client-id",
+				"--tenant-id", "test-tenant-id",
+				"--login", "azurecli",
+			},
+		},
+		{
+			name: "Service Principal",
+			options: ExecCredentialOptions{
+				Environment: "AzureChinaCloud",
+				LoginMode:   ExecLoginModeServicePrincipal,
+				ClientID:    "test-service-principal-id",
+			},
+			expectedArgs: []string{
+				"get-token",
+				"--environment", "AzureChinaCloud",
+				"--server-id", "test-server-id",
+				"--client-id", "test-service-principal-id",
+				"--tenant-id", "test-tenant-id",
+				"--login", "spn",
+			},
+		},
+		{
+			name: "System Assigned Managed Service Identity",
+			options: ExecCredentialOptions{
+				Environment: "AzurePublicCloud",
+				LoginMode:   ExecLoginModeManagedServiceIdentity,
+			},
+			expectedArgs: []string{
+				"get-token",
+				"--environment", "AzurePublicCloud",
+				"--server-id", "test-server-id",
+				"--tenant-id", "test-tenant-id",
+				"--login", "msi",
+			},
+		},
+		{
+			name: "User Assigned Managed Service Identity",
+			options: ExecCredentialOptions{
+				Environment: "AzurePublicCloud",
+				LoginMode:   ExecLoginModeManagedServiceIdentity,
+				ClientID:    "test-identity-client-id",
+			},
+			expectedArgs: []string{
+				"get-token",
+				"--environment", "AzurePublicCloud",
+				"--server-id", "test-server-id",
+				"--client-id", "test-identity-client-id",
+				"--tenant-id", "test-tenant-id",
+				"--login", "msi",
+			},
+		},
+		{
+			name: "Service Principal without Client ID",
+			options: ExecCredentialOptions{
+				Environment: "AzurePublicCloud",
+				LoginMode:   ExecLoginModeServicePrincipal,
+			},
+			expectError: true,
+		},
+		{
+			name: "No Environment",
+			options: ExecCredentialOptions{
+				LoginMode: ExecLoginModeAzureCLI,
+			},
+			expectError: true,
+		},
+	}
+
+	kubeConfigAAD, err := ParseKubeConfigAAD(LoadConfig("user_with_aad.yml"))
+	if err != nil {
+		t.Fatalf("Failed to parse AAD config: %+v", err)
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := NewKubeConfigExec(*kubeConfigAAD, test.options)
+			if test.expectError {
+				if err == nil {
+					t.Fatalf("Expected an error but didn't get one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+
+			if !reflect.DeepEqual(kubeConfigAAD.KubeConfigBase, result.KubeConfigBase) {
+				t.Fatalf("Expected the clusters and contexts to be retained but got %+v", result.KubeConfigBase)
+			}
+
+			if len(result.Users) != 1 {
+				t.Fatalf("Expected 1 user but got %d", len(result.Users))
+			}
+
+			user := result.Users[0]
+			if user.Name != "clusterUser_test-rg_test-cluster" {
+				t.Fatalf("Expected the user name to be retained but got %q", user.Name)
+			}
+			if user.User.Exec.APIVersion != ExecCredentialAPIVersion || user.User.Exec.Command != ExecCredentialCommand {
+				t.Fatalf("Expected the exec plugin to be %q (%q) but got %q (%q)", ExecCredentialCommand, ExecCredentialAPIVersion, user.User.Exec.Command, user.User.Exec.APIVersion)
+			}
+			if !reflect.DeepEqual(test.expectedArgs, user.User.Exec.Args) {
+				t.Fatalf("Expected args %+v but got %+v", test.expectedArgs, user.User.Exec.Args)
+			}
+		})
+	}
+}
+
+func TestNewExecCredentialOptions(t *testing.T) {
+	testCases := []struct {
+		name              string
+		auth              ExecCredentialAuthentication
+		expectedLoginMode string
+		expectedClientID  string
+		expectError       bool
+	}{
+		{
+			name: "Azure CLI",
+			auth: ExecCredentialAuthentication{
+				Environment: "AzurePublicCloud",
+				ClientID:    "04b07795-8ddb-461a-bbee-02f9e1bf7b46",
+			},
+			expectedLoginMode: ExecLoginModeAzureCLI,
+		},
+		{
+			name: "Service Principal with a Client Secret",
+			auth: ExecCredentialAuthentication{
+				Environment:           "AzurePublicCloud",
+				ClientID:              "test-service-principal-id",
+				UsingServicePrincipal: true,
+			},
+			expectedLoginMode: ExecLoginModeServicePrincipal,
+			expectedClientID:  "test-service-principal-id",
+		},
+		{
+			name: "Service Principal with a Client Certificate",
+			auth: ExecCredentialAuthentication{
+				Environment:            "AzurePublicCloud",
+				ClientID:               "test-service-principal-id",
+				UsingServicePrincipal:  true,
+				UsingClientCertificate: true,
+			},
+			expectError: true,
+		},
+		{
+			name: "System Assigned Managed Service Identity",
+			auth: ExecCredentialAuthentication{
+				Environment:                 "AzurePublicCloud",
+				UsingManagedServiceIdentity: true,
+			},
+			expectedLoginMode: ExecLoginModeManagedServiceIdentity,
+		},
+		{
+			name: "User Assigned Managed Service Identity",
+			auth: ExecCredentialAuthentication{
+				Environment:                 "AzurePublicCloud",
+				ClientID:                    "test-identity-client-id",
+				UsingManagedServiceIdentity: true,
+			},
+			expectedLoginMode: ExecLoginModeManagedServiceIdentity,
+			expectedClientID:  "test-identity-client-id",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := NewExecCredentialOptions(test.auth)
+			if test.expectError {
+				if err == nil {
+					t.Fatalf("Expected an error but didn't get one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+
+			if result.Environment != test.auth.Environment {
+				t.Fatalf("Expected the Environment to be %q but got %q", test.auth.Environment, result.Environment)
+			}
+			if result.LoginMode != test.expectedLoginMode {
+				t.Fatalf("Expected the Login Mode to be %q but got %q", test.expectedLoginMode, result.LoginMode)
+			}
+			if result.ClientID != test.expectedClientID {
+				t.Fatalf("Expected the Client ID to be %q but got %q", test.expectedClientID, result.ClientID)
+			}
+		})
+	}
+}
+
+func TestKubeConfigExecRaw(t *testing.T) {
+	kubeConfigAAD, err := ParseKubeConfigAAD(LoadConfig("user_with_aad.yml"))
+	if err != nil {
+		t.Fatalf("Failed to parse AAD config: %+v", err)
+	}
+
+	kubeConfigExec, err := NewKubeConfigExec(*kubeConfigAAD, ExecCredentialOptions{
+		Environment: "AzurePublicCloud",
+		LoginMode:   ExecLoginModeAzureCLI,
+	})
+	if err != nil {
+		t.Fatalf("Failed to convert AAD config: %+v", err)
+	}
+
+	raw, err := kubeConfigExec.Raw()
+	if err != nil {
+		t.Fatalf("Failed to serialize config: %+v", err)
+	}
+
+	if strings.Contains(raw, "auth-provider") {
+		t.Fatalf("Expected the auth-provider to be removed but got:\n%s", raw)
+	}
+
+	for _, expected := range []string{"exec:", "command: kubelogin", "server: https://testcluster.org:443", "current-context: test-cluster"} {
+		if !strings.Contains(raw, expected) {
+			t.Fatalf("Expected the config to contain %q but got:\n%s", expected, raw)
+		}
+	}
+
+	// the generated config must be usable as an AAD config's cluster definition
+	if _, err := ParseKubeConfigAAD(raw); err != nil {
+		t.Fatalf("Failed to parse the generated config: %+v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type version struct {
+	major int
+	minor int
+	patch int
+}
+
+func parseVersion(input string) (*version, error) {
+	segments := strings.Split(strings.TrimPrefix(input, "v"), ".")
+	if len(segments) != 3 {
+		return nil, fmt.Errorf("%q is not a valid Kubernetes Version - expected the format `major.minor.patch`", input)
+	}
+
+	values := make([]int, 0)
+	for _, segment := range segments {
+		value, err := strconv.Atoi(segment)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("%q is not a valid Kubernetes Version - expected the format `major.minor.patch`", input)
+		}
+		values = append(values, value)
+	}
+
+	return &version{
+		major: values[0],
+		minor: values[1],
+		patch: values[2],
+	}, nil
+}
+
+// ValidateVersionUpgrade ensures that a Kubernetes Cluster can be upgraded from the current version to the
+// target version - which must be newer and cannot skip a minor version (e.g. 1.11.x -> 1.13.x)
+func ValidateVersionUpgrade(current string, target string) error {
+	from, err := parseVersion(current)
+	if err != nil {
+		return err
+	}
+
+	to, err := parseVersion(target)
+	if err != nil {
+		return err
+	}
+
+	if to.major != from.major {
+		return fmt.Errorf("Kubernetes Version %q cannot be upgraded to %q since the major version can't be changed", current, target)
+	}
+
+	if to.minor < from.minor || (to.minor == from.minor && to.patch < from.patch) {
+		return fmt.Errorf("Kubernetes Version %q cannot be downgraded to %q", current, target)
+	}
+
+	if to.minor > from.minor+1 {
+		return fmt.Errorf("Kubernetes Version %q cannot be upgraded to %q since minor versions can't be skipped - upgrade to %d.%d.x first", current, target, from.major, from.minor+1)
+	}
+
+	return nil
+}
+
+// CompareVersions returns -1 if the first Kubernetes Version is older than the second, 1 if it's newer
+// and 0 if they're the same version
+func CompareVersions(first string, second string) (int, error) {
+	a, err := parseVersion(first)
+	if err != nil {
+		return 0, err
+	}
+
+	b, err := parseVersion(second)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, v := range [][2]int{{a.major, b.major}, {a.minor, b.minor}, {a.patch, b.patch}} {
+		if v[0] < v[1] {
+			return -1, nil
+		}
+		if v[0] > v[1] {
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
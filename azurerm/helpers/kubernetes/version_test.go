@@ -0,0 +1,60 @@
+package kubernetes
+
+import "testing"
+
+func TestValidateVersionUpgrade(t *testing.T) {
+	cases := []struct {
+		Current string
+		Target  string
+		Error   bool
+	}{
+		{Current: "1.12.7", Target: "1.12.7", Error: false},
+		{Current: "1.12.6", Target: "1.12.7", Error: false},
+		{Current: "1.12.7", Target: "1.13.5", Error: false},
+		{Current: "1.11.9", Target: "1.13.5", Error: true},
+		{Current: "1.13.5", Target: "1.12.7", Error: true},
+		{Current: "1.12.7", Target: "1.12.6", Error: true},
+		{Current: "1.12.7", Target: "2.0.0", Error: true},
+		{Current: "1.12", Target: "1.13.5", Error: true},
+		{Current: "1.12.7", Target: "1.13.x", Error: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Current+"_"+tc.Target, func(t *testing.T) {
+			err := ValidateVersionUpgrade(tc.Current, tc.Target)
+			if (err != nil) != tc.Error {
+				t.Fatalf("Expected ValidateVersionUpgrade(%q, %q) to return an error (%t) but got: %+v", tc.Current, tc.Target, tc.Error, err)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		First    string
+		Second   string
+		Expected int
+		Error    bool
+	}{
+		{First: "1.12.7", Second: "1.12.7", Expected: 0},
+		{First: "1.12.6", Second: "1.12.7", Expected: -1},
+		{First: "1.13.5", Second: "1.12.7", Expected: 1},
+		{First: "1.9.11", Second: "1.10.1", Expected: -1},
+		{First: "2.0.0", Second: "1.13.5", Expected: 1},
+		{First: "1.12", Second: "1.12.7", Error: true},
+		{First: "1.12.7", Second: "1.13.x", Error: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.First+"_"+tc.Second, func(t *testing.T) {
+			actual, err := CompareVersions(tc.First, tc.Second)
+			if (err != nil) != tc.Error {
+				t.Fatalf("Expected CompareVersions(%q, %q) to return an error (%t) but got: %+v", tc.First, tc.Second, tc.Error, err)
+			}
+
+			if actual != tc.Expected {
+				t.Fatalf("Expected CompareVersions(%q, %q) to return %d but got %d", tc.First, tc.Second, tc.Expected, actual)
+			}
+		})
+	}
+}
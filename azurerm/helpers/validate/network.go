@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"strings"
 )
 
 func IPv6Address(i interface{}, k string) (warnings []string, errors []error) {
@@ -41,6 +42,110 @@ func CIDR(i interface{}, k string) (warnings []string, errors []error) {
 	return warnings, errors
 }
 
+// CIDRv6 validates that the value is an IPv6 address with a prefix length, e.g. `ace:cab:deca::/48`.
+func CIDRv6(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	ip, _, err := net.ParseCIDR(v)
+	if err != nil || ip.To4() != nil {
+		errors = append(errors, fmt.Errorf("%q must be an IPv6 address with a prefix length. Example: ace:cab:deca::/48. Got %q.", k, v))
+	}
+
+	return warnings, errors
+}
+
+// CIDRv4OrV6 validates that the value is either an IPv4 or an IPv6 CIDR block.
+func CIDRv4OrV6(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if strings.Contains(v, ":") {
+		return CIDRv6(v, k)
+	}
+
+	return CIDR(v, k)
+}
+
+// IPAddressOrEmpty validates that the value is an IPv4 or IPv6 address, or is empty.
+func IPAddressOrEmpty(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if v == "" {
+		return
+	}
+
+	if ip := net.ParseIP(v); ip == nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid IP address: %q", k, v))
+	}
+
+	return warnings, errors
+}
+
+// IPAddressMatchesVersion checks that the address belongs to the given IP version (`IPv4` or `IPv6`).
+func IPAddressMatchesVersion(address string, version string) error {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return fmt.Errorf("%q is not a valid IP address", address)
+	}
+
+	isV4 := ip.To4() != nil
+	if strings.EqualFold(version, "IPv6") && isV4 {
+		return fmt.Errorf("%q is an IPv4 address but the IP version is %q", address, version)
+	}
+	if strings.EqualFold(version, "IPv4") && !isV4 {
+		return fmt.Errorf("%q is an IPv6 address but the IP version is %q", address, version)
+	}
+
+	return nil
+}
+
+// DualStackAddressPrefixes validates a list of address prefixes which may mix IPv4 and IPv6 blocks.
+// Azure requires at least one IPv4 prefix and allows at most one IPv6 prefix, and prefixes may not be repeated.
+func DualStackAddressPrefixes(prefixes []string) error {
+	seen := make(map[string]bool)
+	ipv4Count := 0
+	ipv6Count := 0
+
+	for _, prefix := range prefixes {
+		ip, ipNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid CIDR block: %+v", prefix, err)
+		}
+
+		if seen[ipNet.String()] {
+			return fmt.Errorf("the address prefix %q is specified more than once", prefix)
+		}
+		seen[ipNet.String()] = true
+
+		if ip.To4() != nil {
+			ipv4Count++
+		} else {
+			ipv6Count++
+		}
+	}
+
+	if ipv4Count == 0 {
+		return fmt.Errorf("at least one IPv4 address prefix must be specified")
+	}
+
+	if ipv6Count > 1 {
+		return fmt.Errorf("at most one IPv6 address prefix can be specified, got %d", ipv6Count)
+	}
+
+	return nil
+}
+
 func IPv4Address(i interface{}, k string) (warnings []string, errors []error) {
 	return validateIpv4Address(i, k, false)
 }
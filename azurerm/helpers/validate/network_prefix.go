@@ -0,0 +1,118 @@
+package validate
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// NextAvailableAddressPrefix returns the first CIDR block with the specified prefix length within the
+// parent address spaces (searched in order) which doesn't overlap any of the existing address prefixes
+func NextAvailableAddressPrefix(parents []string, existing []string, prefixLength int) (string, error) {
+	used := make([]*net.IPNet, 0)
+	for _, prefix := range existing {
+		_, ipNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a valid CIDR block: %+v", prefix, err)
+		}
+		used = append(used, ipNet)
+	}
+
+	for _, parent := range parents {
+		_, parentNet, err := net.ParseCIDR(parent)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a valid CIDR block: %+v", parent, err)
+		}
+
+		parentLength, bits := parentNet.Mask.Size()
+		if prefixLength < parentLength || prefixLength > bits {
+			continue
+		}
+
+		if prefix := nextAvailablePrefixInParent(parentNet, used, prefixLength); prefix != nil {
+			return prefix.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("there is no free /%d address prefix within %q which doesn't overlap the existing address prefixes", prefixLength, parents)
+}
+
+// AddressPrefixesOverlap returns whether the two CIDR blocks share any addresses
+func AddressPrefixesOverlap(first *net.IPNet, second *net.IPNet) bool {
+	return first.Contains(second.IP) || second.Contains(first.IP)
+}
+
+func nextAvailablePrefixInParent(parent *net.IPNet, used []*net.IPNet, prefixLength int) *net.IPNet {
+	_, bits := parent.Mask.Size()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-prefixLength))
+	parentStart, parentEnd := addressPrefixRange(parent)
+
+	candidate := new(big.Int).Set(parentStart)
+	for {
+		candidateEnd := new(big.Int).Add(candidate, size)
+		candidateEnd.Sub(candidateEnd, big.NewInt(1))
+		if candidateEnd.Cmp(parentEnd) > 0 {
+			return nil
+		}
+
+		candidateNet := &net.IPNet{
+			IP:   bigIntToIP(candidate, bits),
+			Mask: net.CIDRMask(prefixLength, bits),
+		}
+
+		var overlapping *net.IPNet
+		for _, existing := range used {
+			if _, existingBits := existing.Mask.Size(); existingBits != bits {
+				continue
+			}
+
+			if AddressPrefixesOverlap(candidateNet, existing) {
+				overlapping = existing
+				break
+			}
+		}
+
+		if overlapping == nil {
+			return candidateNet
+		}
+
+		// skip past the overlapping prefix, keeping the candidate aligned to the prefix length
+		_, overlappingEnd := addressPrefixRange(overlapping)
+		next := new(big.Int).Add(overlappingEnd, big.NewInt(1))
+		if remainder := new(big.Int).Mod(next, size); remainder.Sign() != 0 {
+			next.Add(next, new(big.Int).Sub(size, remainder))
+		}
+		if next.Cmp(candidate) <= 0 {
+			next = new(big.Int).Add(candidate, size)
+		}
+		candidate = next
+	}
+}
+
+func addressPrefixRange(ipNet *net.IPNet) (*big.Int, *big.Int) {
+	ones, bits := ipNet.Mask.Size()
+	start := ipToBigInt(ipNet.IP.Mask(ipNet.Mask), bits)
+
+	end := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	end.Add(end, start)
+	end.Sub(end, big.NewInt(1))
+
+	return start, end
+}
+
+func ipToBigInt(ip net.IP, bits int) *big.Int {
+	if bits == 32 {
+		ip = ip.To4()
+	} else {
+		ip = ip.To16()
+	}
+
+	return new(big.Int).SetBytes(ip)
+}
+
+func bigIntToIP(input *big.Int, bits int) net.IP {
+	raw := input.Bytes()
+	ip := make(net.IP, bits/8)
+	copy(ip[len(ip)-len(raw):], raw)
+	return ip
+}
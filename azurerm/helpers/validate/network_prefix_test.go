@@ -0,0 +1,175 @@
+package validate
+
+import (
+	"net"
+	"testing"
+)
+
+func TestNextAvailableAddressPrefix(t *testing.T) {
+	cases := []struct {
+		Name         string
+		Parents      []string
+		Existing     []string
+		PrefixLength int
+		Expected     string
+		Error        bool
+	}{
+		{
+			Name:         "Empty Address Space",
+			Parents:      []string{"10.0.0.0/16"},
+			Existing:     []string{},
+			PrefixLength: 24,
+			Expected:     "10.0.0.0/24",
+		},
+		{
+			Name:         "Whole Address Space",
+			Parents:      []string{"10.0.0.0/16"},
+			Existing:     []string{},
+			PrefixLength: 16,
+			Expected:     "10.0.0.0/16",
+		},
+		{
+			Name:         "Parent Not Aligned",
+			Parents:      []string{"10.0.1.0/16"},
+			Existing:     []string{},
+			PrefixLength: 24,
+			Expected:     "10.0.0.0/24",
+		},
+		{
+			Name:         "After Existing",
+			Parents:      []string{"10.0.0.0/16"},
+			Existing:     []string{"10.0.0.0/24", "10.0.1.0/24"},
+			PrefixLength: 24,
+			Expected:     "10.0.2.0/24",
+		},
+		{
+			Name:         "Fills Gap",
+			Parents:      []string{"10.0.0.0/16"},
+			Existing:     []string{"10.0.0.0/24", "10.0.2.0/24"},
+			PrefixLength: 24,
+			Expected:     "10.0.1.0/24",
+		},
+		{
+			Name:         "Gap Too Small",
+			Parents:      []string{"10.0.0.0/16"},
+			Existing:     []string{"10.0.0.0/24", "10.0.2.0/24"},
+			PrefixLength: 23,
+			Expected:     "10.0.4.0/23",
+		},
+		{
+			Name:         "Smaller Existing Prefix",
+			Parents:      []string{"10.0.0.0/16"},
+			Existing:     []string{"10.0.0.16/28"},
+			PrefixLength: 28,
+			Expected:     "10.0.0.0/28",
+		},
+		{
+			Name:         "Larger Existing Prefix",
+			Parents:      []string{"10.0.0.0/16"},
+			Existing:     []string{"10.0.0.0/20"},
+			PrefixLength: 24,
+			Expected:     "10.0.16.0/24",
+		},
+		{
+			Name:         "Next Address Space",
+			Parents:      []string{"10.0.0.0/24", "10.1.0.0/16"},
+			Existing:     []string{"10.0.0.0/25", "10.0.0.128/25"},
+			PrefixLength: 25,
+			Expected:     "10.1.0.0/25",
+		},
+		{
+			Name:         "Prefix Length Larger Than First Address Space",
+			Parents:      []string{"10.0.0.0/24", "10.1.0.0/16"},
+			Existing:     []string{},
+			PrefixLength: 20,
+			Expected:     "10.1.0.0/20",
+		},
+		{
+			Name:         "Existing Outside Address Space",
+			Parents:      []string{"10.0.0.0/16"},
+			Existing:     []string{"192.168.0.0/24"},
+			PrefixLength: 24,
+			Expected:     "10.0.0.0/24",
+		},
+		{
+			Name:         "Exhausted",
+			Parents:      []string{"10.0.0.0/23"},
+			Existing:     []string{"10.0.0.0/24", "10.0.1.0/24"},
+			PrefixLength: 24,
+			Error:        true,
+		},
+		{
+			Name:         "Prefix Length Too Small",
+			Parents:      []string{"10.0.0.0/16"},
+			Existing:     []string{},
+			PrefixLength: 8,
+			Error:        true,
+		},
+		{
+			Name:         "End Of IPv4 Range",
+			Parents:      []string{"255.255.255.0/24"},
+			Existing:     []string{"255.255.255.0/25"},
+			PrefixLength: 25,
+			Expected:     "255.255.255.128/25",
+		},
+		{
+			Name:         "IPv6",
+			Parents:      []string{"10.0.0.0/16", "ace:cab:deca::/48"},
+			Existing:     []string{"10.0.0.0/24", "ace:cab:deca::/64"},
+			PrefixLength: 64,
+			Expected:     "ace:cab:deca:1::/64",
+		},
+		{
+			Name:         "Invalid Parent",
+			Parents:      []string{"not-a-cidr"},
+			Existing:     []string{},
+			PrefixLength: 24,
+			Error:        true,
+		},
+		{
+			Name:         "Invalid Existing",
+			Parents:      []string{"10.0.0.0/16"},
+			Existing:     []string{"10.0.0.0/33"},
+			PrefixLength: 24,
+			Error:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := NextAvailableAddressPrefix(tc.Parents, tc.Existing, tc.PrefixLength)
+			if (err != nil) != tc.Error {
+				t.Fatalf("Expected NextAvailableAddressPrefix to return an error (%t) but got: %+v", tc.Error, err)
+			}
+
+			if actual != tc.Expected {
+				t.Fatalf("Expected NextAvailableAddressPrefix to return %q but got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestAddressPrefixesOverlap(t *testing.T) {
+	cases := []struct {
+		First    string
+		Second   string
+		Expected bool
+	}{
+		{First: "10.0.0.0/24", Second: "10.0.1.0/24", Expected: false},
+		{First: "10.0.0.0/24", Second: "10.0.0.0/24", Expected: true},
+		{First: "10.0.0.0/16", Second: "10.0.5.0/24", Expected: true},
+		{First: "10.0.5.0/24", Second: "10.0.0.0/16", Expected: true},
+		{First: "10.0.0.0/25", Second: "10.0.0.128/25", Expected: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.First+"_"+tc.Second, func(t *testing.T) {
+			_, first, _ := net.ParseCIDR(tc.First)
+			_, second, _ := net.ParseCIDR(tc.Second)
+
+			if actual := AddressPrefixesOverlap(first, second); actual != tc.Expected {
+				t.Fatalf("Expected AddressPrefixesOverlap to return %t but got %t", tc.Expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package validate
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// networkSecurityRuleServiceTags are the Service Tags which can be used in the address prefixes of a
+// Network Security Rule, mapped to whether the tag can be scoped to a region (e.g. `Storage.WestEurope`)
+var networkSecurityRuleServiceTags = map[string]bool{
+	"ApiManagement":                      true,
+	"AppService":                         true,
+	"AppServiceManagement":               false,
+	"AzureActiveDirectory":               false,
+	"AzureActiveDirectoryDomainServices": false,
+	"AzureBackup":                        false,
+	"AzureCloud":                         true,
+	"AzureConnectors":                    true,
+	"AzureContainerRegistry":             true,
+	"AzureCosmosDB":                      true,
+	"AzureDataLake":                      false,
+	"AzureFrontDoor.Backend":             false,
+	"AzureFrontDoor.FirstParty":          false,
+	"AzureFrontDoor.Frontend":            false,
+	"AzureKeyVault":                      true,
+	"AzureLoadBalancer":                  false,
+	"AzureMachineLearning":               false,
+	"AzureMonitor":                       false,
+	"AzurePlatformDNS":                   false,
+	"AzurePlatformIMDS":                  false,
+	"AzurePlatformLKM":                   false,
+	"AzureResourceManager":               false,
+	"AzureTrafficManager":                false,
+	"BatchNodeManagement":                true,
+	"CognitiveServicesManagement":        false,
+	"DataFactory":                        true,
+	"EventHub":                           true,
+	"GatewayManager":                     false,
+	"HDInsight":                          true,
+	"Internet":                           false,
+	"MicrosoftContainerRegistry":         true,
+	"ServiceBus":                         true,
+	"ServiceFabric":                      false,
+	"Sql":                                true,
+	"SqlManagement":                      false,
+	"Storage":                            true,
+	"VirtualNetwork":                     false,
+
+	// the legacy names of the default tags are still accepted by the API
+	"AZURE_LOADBALANCER": false,
+	"INTERNET":           false,
+	"VIRTUAL_NETWORK":    false,
+}
+
+var networkSecurityRuleRegionRegex = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+// networkSecurityRuleIPAddressRegex matches values which look like an IPv4 or IPv6 address, rather than a Service Tag
+var networkSecurityRuleIPAddressRegex = regexp.MustCompile(`^([0-9.]+|[0-9a-fA-F:.]*:[0-9a-fA-F:.]*)$`)
+
+// NetworkSecurityRuleAddressPrefix validates that the value is either `*`, an IP address or a CIDR block.
+// Any other value is treated as a Service Tag - since new Service Tags are regularly added a warning
+// (rather than an error) is returned for tags which aren't known, so that these can still be used
+func NetworkSecurityRuleAddressPrefix(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if v == "" || v == "*" {
+		return
+	}
+
+	if strings.Contains(v, "/") {
+		if _, _, err := net.ParseCIDR(v); err != nil {
+			errors = append(errors, fmt.Errorf("%q is not a valid CIDR block: %q", k, v))
+		}
+		return
+	}
+
+	if networkSecurityRuleIPAddressRegex.MatchString(v) {
+		if ip := net.ParseIP(v); ip == nil {
+			errors = append(errors, fmt.Errorf("%q is not a valid IP address: %q", k, v))
+		}
+		return
+	}
+
+	if !isNetworkSecurityRuleServiceTag(v) {
+		warnings = append(warnings, fmt.Sprintf("%q isn't a known Service Tag (such as `VirtualNetwork`, `Internet` or `Storage.WestEurope`) - got %q. If this isn't a new Service Tag, the rule will be rejected by Azure", k, v))
+	}
+
+	return warnings, errors
+}
+
+func isNetworkSecurityRuleServiceTag(input string) bool {
+	for tag, regional := range networkSecurityRuleServiceTags {
+		if strings.EqualFold(input, tag) {
+			return true
+		}
+
+		if !regional {
+			continue
+		}
+
+		prefix := tag + "."
+		if len(input) > len(prefix) && strings.EqualFold(input[:len(prefix)], prefix) {
+			if networkSecurityRuleRegionRegex.MatchString(input[len(prefix):]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
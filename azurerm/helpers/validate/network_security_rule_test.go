@@ -0,0 +1,49 @@
+package validate
+
+import "testing"
+
+func TestNetworkSecurityRuleAddressPrefix(t *testing.T) {
+	cases := []struct {
+		Value    string
+		Warnings int
+		Errors   int
+	}{
+		{Value: "*"},
+		{Value: "10.0.0.1"},
+		{Value: "10.0.0.0/24"},
+		{Value: "10.0.0.0/33", Errors: 1},
+		{Value: "ace:cab:deca::/48"},
+		{Value: "ace:cab:deca::1"},
+		{Value: "VirtualNetwork"},
+		{Value: "virtualnetwork"},
+		{Value: "Internet"},
+		{Value: "AzureLoadBalancer"},
+		{Value: "VIRTUAL_NETWORK"},
+		{Value: "Storage"},
+		{Value: "Storage.WestEurope"},
+		{Value: "Sql.EastUS2"},
+		{Value: "AzureFrontDoor.Backend"},
+		{Value: "AzureDevOps", Warnings: 1},
+		{Value: "WindowsVirtualDesktop", Warnings: 1},
+		{Value: "AzureIoTHub.WestEurope", Warnings: 1},
+		{Value: "Internet.WestEurope", Warnings: 1},
+		{Value: "VirtualNetworks", Warnings: 1},
+		{Value: "10.0.0", Errors: 1},
+		{Value: "10.0.0.256", Errors: 1},
+		{Value: "ace:cab:deca:::1", Errors: 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Value, func(t *testing.T) {
+			warnings, errors := NetworkSecurityRuleAddressPrefix(tc.Value, "source_address_prefix")
+
+			if len(warnings) != tc.Warnings {
+				t.Fatalf("Expected NetworkSecurityRuleAddressPrefix to return %d warning(s) not %d", tc.Warnings, len(warnings))
+			}
+
+			if len(errors) != tc.Errors {
+				t.Fatalf("Expected NetworkSecurityRuleAddressPrefix to return %d error(s) not %d", tc.Errors, len(errors))
+			}
+		})
+	}
+}
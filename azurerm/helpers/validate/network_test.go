@@ -43,6 +43,189 @@ func TestCIDR(t *testing.T) {
 	}
 }
 
+func TestCIDRv6(t *testing.T) {
+	cases := []struct {
+		CIDR   string
+		Errors int
+	}{
+		{
+			CIDR:   "",
+			Errors: 1,
+		},
+		{
+			CIDR:   "10.0.0.0/16",
+			Errors: 1,
+		},
+		{
+			CIDR:   "ace:cab:deca::",
+			Errors: 1,
+		},
+		{
+			CIDR:   "ace:cab:deca::/48",
+			Errors: 0,
+		},
+		{
+			CIDR:   "ace:cab:deca:deed::/64",
+			Errors: 0,
+		},
+		{
+			CIDR:   "ace:cab:deca::/129",
+			Errors: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.CIDR, func(t *testing.T) {
+			_, errors := CIDRv6(tc.CIDR, "test")
+
+			if len(errors) != tc.Errors {
+				t.Fatalf("Expected CIDRv6 to return %d error(s) not %d", tc.Errors, len(errors))
+			}
+		})
+	}
+}
+
+func TestCIDRv4OrV6(t *testing.T) {
+	cases := []struct {
+		CIDR   string
+		Errors int
+	}{
+		{
+			CIDR:   "",
+			Errors: 1,
+		},
+		{
+			CIDR:   "10.0.0.0/16",
+			Errors: 0,
+		},
+		{
+			CIDR:   "10.0.0.0/33",
+			Errors: 1,
+		},
+		{
+			CIDR:   "ace:cab:deca::/48",
+			Errors: 0,
+		},
+		{
+			CIDR:   "ace:cab:deca::",
+			Errors: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.CIDR, func(t *testing.T) {
+			_, errors := CIDRv4OrV6(tc.CIDR, "test")
+
+			if len(errors) != tc.Errors {
+				t.Fatalf("Expected CIDRv4OrV6 to return %d error(s) not %d", tc.Errors, len(errors))
+			}
+		})
+	}
+}
+
+func TestDualStackAddressPrefixes(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Prefixes []string
+		Error    bool
+	}{
+		{
+			Name:     "Empty",
+			Prefixes: []string{},
+			Error:    true,
+		},
+		{
+			Name:     "Single IPv4",
+			Prefixes: []string{"10.0.1.0/24"},
+			Error:    false,
+		},
+		{
+			Name:     "IPv6 Only",
+			Prefixes: []string{"ace:cab:deca:deed::/64"},
+			Error:    true,
+		},
+		{
+			Name:     "Dual Stack",
+			Prefixes: []string{"10.0.1.0/24", "ace:cab:deca:deed::/64"},
+			Error:    false,
+		},
+		{
+			Name:     "Multiple IPv4",
+			Prefixes: []string{"10.0.1.0/24", "10.0.2.0/24", "ace:cab:deca:deed::/64"},
+			Error:    false,
+		},
+		{
+			Name:     "Multiple IPv6",
+			Prefixes: []string{"10.0.1.0/24", "ace:cab:deca:deed::/64", "ace:cab:deca:beef::/64"},
+			Error:    true,
+		},
+		{
+			Name:     "Duplicate",
+			Prefixes: []string{"10.0.1.0/24", "10.0.1.0/24"},
+			Error:    true,
+		},
+		{
+			Name:     "Invalid",
+			Prefixes: []string{"10.0.1.0/24", "not-a-cidr"},
+			Error:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := DualStackAddressPrefixes(tc.Prefixes)
+
+			if (err != nil) != tc.Error {
+				t.Fatalf("Expected DualStackAddressPrefixes to return an error (%t) but got: %+v", tc.Error, err)
+			}
+		})
+	}
+}
+
+func TestIPAddressMatchesVersion(t *testing.T) {
+	cases := []struct {
+		Address string
+		Version string
+		Error   bool
+	}{
+		{
+			Address: "10.0.1.4",
+			Version: "IPv4",
+			Error:   false,
+		},
+		{
+			Address: "10.0.1.4",
+			Version: "IPv6",
+			Error:   true,
+		},
+		{
+			Address: "ace:cab:deca:deed::4",
+			Version: "IPv6",
+			Error:   false,
+		},
+		{
+			Address: "ace:cab:deca:deed::4",
+			Version: "IPv4",
+			Error:   true,
+		},
+		{
+			Address: "text",
+			Version: "IPv4",
+			Error:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Address+"/"+tc.Version, func(t *testing.T) {
+			err := IPAddressMatchesVersion(tc.Address, tc.Version)
+
+			if (err != nil) != tc.Error {
+				t.Fatalf("Expected IPAddressMatchesVersion to return an error (%t) but got: %+v", tc.Error, err)
+			}
+		})
+	}
+}
+
 func TestIPv6Address(t *testing.T) {
 	cases := []struct {
 		IP     string
@@ -194,6 +377,40 @@ func TestIPv4AddressOrEmpty(t *testing.T) {
 	}
 }
 
+func TestIPAddressOrEmpty(t *testing.T) {
+	cases := []struct {
+		IP     string
+		Errors int
+	}{
+		{
+			IP:     "",
+			Errors: 0,
+		},
+		{
+			IP:     "10.0.1.4",
+			Errors: 0,
+		},
+		{
+			IP:     "ace:cab:deca:deed::4",
+			Errors: 0,
+		},
+		{
+			IP:     "text",
+			Errors: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.IP, func(t *testing.T) {
+			_, errors := IPAddressOrEmpty(tc.IP, "test")
+
+			if len(errors) != tc.Errors {
+				t.Fatalf("Expected IPAddressOrEmpty to return %d error(s) not %d", tc.Errors, len(errors))
+			}
+		})
+	}
+}
+
 func TestMACAddress(t *testing.T) {
 	cases := []struct {
 		MAC    string
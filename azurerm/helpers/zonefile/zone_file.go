@@ -0,0 +1,586 @@
+package zonefile
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RecordSet is a group of records in a zone sharing the same name and type
+type RecordSet struct {
+	// Name is the name of the Record Set relative to the zone, where `@` denotes the apex
+	Name string
+
+	// Type is the upper-case record type, e.g. `A` or `MX`
+	Type string
+
+	TTL int64
+
+	// Records contains the canonical representation of the data for each record, which
+	// can be split back into its fields using SplitRecordData
+	Records []string
+}
+
+// Key returns a unique identifier for the Record Set within a zone
+func (rs RecordSet) Key() string {
+	return fmt.Sprintf("%s/%s", rs.Name, rs.Type)
+}
+
+// IsZoneAuthority returns whether this is the SOA or NS Record Set at the apex of the zone,
+// which are created and managed by the DNS service itself
+func (rs RecordSet) IsZoneAuthority() bool {
+	return rs.Name == "@" && (rs.Type == "SOA" || rs.Type == "NS")
+}
+
+type line struct {
+	number     int
+	tokens     []token
+	blankOwner bool
+}
+
+type token struct {
+	value  string
+	quoted bool
+}
+
+// Parse parses the RFC 1035 Master File in `input` into a list of Record Sets for the zone `zoneName`.
+//
+// `$ORIGIN` and `$TTL` directives are supported, as are relative and absolute names, blank owners,
+// parentheses spanning multiple lines and comments. The `defaultTTL` is used for records where
+// no TTL is specified, either explicitly or through a `$TTL` directive. Where the records in a
+// Record Set have different TTLs the lowest one is used, since a Record Set only has a single TTL.
+func Parse(input string, zoneName string, defaultTTL int64) ([]RecordSet, error) {
+	zone := strings.ToLower(strings.TrimSuffix(zoneName, "."))
+	if zone == "" {
+		return nil, fmt.Errorf("a zone name must be specified")
+	}
+
+	lines, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	origin := zone
+	ttl := defaultTTL
+	owner := ""
+
+	sets := make(map[string]*RecordSet)
+	seen := make(map[string]bool)
+
+	for _, l := range lines {
+		if len(l.tokens) == 0 {
+			continue
+		}
+
+		first := l.tokens[0]
+		if !first.quoted && strings.HasPrefix(first.value, "$") && !l.blankOwner {
+			directive := strings.ToUpper(first.value)
+			switch directive {
+			case "$ORIGIN":
+				if len(l.tokens) != 2 {
+					return nil, fmt.Errorf("line %d: `$ORIGIN` expects a single domain name", l.number)
+				}
+				name := l.tokens[1].value
+				if !strings.HasSuffix(name, ".") {
+					name = qualify(name, origin) + "."
+				}
+				origin = strings.ToLower(strings.TrimSuffix(name, "."))
+
+			case "$TTL":
+				if len(l.tokens) != 2 {
+					return nil, fmt.Errorf("line %d: `$TTL` expects a single value", l.number)
+				}
+				v, err := parseTTL(l.tokens[1].value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %+v", l.number, err)
+				}
+				ttl = v
+
+			default:
+				return nil, fmt.Errorf("line %d: the directive %q is not supported", l.number, first.value)
+			}
+			continue
+		}
+
+		tokens := l.tokens
+		if l.blankOwner {
+			if owner == "" {
+				return nil, fmt.Errorf("line %d: a record must specify an owner name before one can be omitted", l.number)
+			}
+		} else {
+			name, err := relativeName(tokens[0].value, origin, zone)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %+v", l.number, err)
+			}
+			owner = name
+			tokens = tokens[1:]
+		}
+
+		recordTTL := ttl
+		explicitTTL := false
+		explicitClass := false
+		for len(tokens) > 0 && !tokens[0].quoted {
+			if v, err := parseTTL(tokens[0].value); err == nil && !explicitTTL {
+				recordTTL = v
+				explicitTTL = true
+				tokens = tokens[1:]
+				continue
+			}
+
+			class := strings.ToUpper(tokens[0].value)
+			if (class == "IN" || class == "CH" || class == "HS" || class == "CS") && !explicitClass {
+				if class != "IN" {
+					return nil, fmt.Errorf("line %d: only the `IN` class is supported, got %q", l.number, tokens[0].value)
+				}
+				explicitClass = true
+				tokens = tokens[1:]
+				continue
+			}
+
+			break
+		}
+
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: expected a record type", l.number)
+		}
+
+		recordType := strings.ToUpper(tokens[0].value)
+		data, err := parseRecordData(recordType, tokens[1:], origin)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %+v", l.number, err)
+		}
+
+		if recordType == "SOA" && owner != "@" {
+			return nil, fmt.Errorf("line %d: a SOA record can only be specified at the apex of the zone", l.number)
+		}
+
+		key := fmt.Sprintf("%s/%s", owner, recordType)
+		set, ok := sets[key]
+		if !ok {
+			set = &RecordSet{
+				Name: owner,
+				Type: recordType,
+				TTL:  recordTTL,
+			}
+			sets[key] = set
+		}
+
+		if recordTTL < set.TTL {
+			set.TTL = recordTTL
+		}
+
+		if seen[key+"/"+data] {
+			continue
+		}
+		seen[key+"/"+data] = true
+		set.Records = append(set.Records, data)
+	}
+
+	results := make([]RecordSet, 0, len(sets))
+	for _, set := range sets {
+		sort.Strings(set.Records)
+		results = append(results, *set)
+	}
+
+	if err := validateRecordSets(results); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Key() < results[j].Key()
+	})
+
+	return results, nil
+}
+
+// SplitRecordData splits the canonical data of a record into its fields,
+// unquoting any quoted values
+func SplitRecordData(data string) ([]string, error) {
+	lines, err := tokenize(data)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0)
+	for _, l := range lines {
+		for _, t := range l.tokens {
+			fields = append(fields, t.value)
+		}
+	}
+
+	return fields, nil
+}
+
+// Quote returns the value as a quoted string, escaping any quotes and backslashes within it
+func Quote(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `"`, `\"`, -1)
+	return `"` + value + `"`
+}
+
+func validateRecordSets(sets []RecordSet) error {
+	typesByName := make(map[string][]string)
+	for _, set := range sets {
+		typesByName[set.Name] = append(typesByName[set.Name], set.Type)
+
+		if set.Type == "CNAME" && len(set.Records) > 1 {
+			return fmt.Errorf("the name %q has more than one CNAME record", set.Name)
+		}
+
+		if set.Type == "SOA" && len(set.Records) > 1 {
+			return fmt.Errorf("the zone has more than one SOA record")
+		}
+	}
+
+	for name, types := range typesByName {
+		for _, t := range types {
+			if t == "CNAME" && len(types) > 1 {
+				return fmt.Errorf("the name %q has a CNAME record and other records, which is not permitted", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func tokenize(input string) ([]line, error) {
+	lines := make([]line, 0)
+	current := line{number: 1}
+	lineNumber := 1
+	depth := 0
+	atLineStart := true
+
+	var buf strings.Builder
+	inToken := false
+
+	flush := func() {
+		if inToken {
+			current.tokens = append(current.tokens, token{value: buf.String()})
+			buf.Reset()
+			inToken = false
+		}
+	}
+
+	runes := []rune(input)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		if atLineStart {
+			atLineStart = false
+			if depth == 0 && (c == ' ' || c == '\t') {
+				current.blankOwner = true
+			}
+		}
+
+		switch {
+		case c == '\n':
+			flush()
+			lineNumber++
+			if depth == 0 {
+				lines = append(lines, current)
+				current = line{number: lineNumber}
+				atLineStart = true
+			}
+
+		case c == '\r':
+			flush()
+
+		case c == ' ' || c == '\t':
+			flush()
+
+		case c == ';':
+			flush()
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+
+		case c == '(':
+			flush()
+			depth++
+
+		case c == ')':
+			flush()
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: unexpected `)`", lineNumber)
+			}
+			depth--
+
+		case c == '"':
+			flush()
+			value, consumed, err := readQuoted(runes[i+1:])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %+v", lineNumber, err)
+			}
+			current.tokens = append(current.tokens, token{value: value, quoted: true})
+			i += consumed
+
+		case c == '\\':
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("line %d: unexpected end of input after `\\`", lineNumber)
+			}
+			r, consumed, err := readEscape(runes[i+1:])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %+v", lineNumber, err)
+			}
+			buf.WriteRune(r)
+			inToken = true
+			i += consumed
+
+		default:
+			buf.WriteRune(c)
+			inToken = true
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", current.number)
+	}
+
+	flush()
+	lines = append(lines, current)
+
+	return lines, nil
+}
+
+// readQuoted reads a quoted string (with the opening quote already consumed), returning
+// the unescaped value and the number of runes consumed including the closing quote
+func readQuoted(runes []rune) (string, int, error) {
+	var buf strings.Builder
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch c {
+		case '"':
+			return buf.String(), i + 1, nil
+
+		case '\\':
+			if i+1 >= len(runes) {
+				return "", 0, fmt.Errorf("unterminated quoted string")
+			}
+			r, consumed, err := readEscape(runes[i+1:])
+			if err != nil {
+				return "", 0, err
+			}
+			buf.WriteRune(r)
+			i += consumed
+
+		case '\n':
+			return "", 0, fmt.Errorf("unterminated quoted string")
+
+		default:
+			buf.WriteRune(c)
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated quoted string")
+}
+
+// readEscape reads either a `\X` or a `\DDD` escape sequence (with the backslash already consumed)
+func readEscape(runes []rune) (rune, int, error) {
+	if len(runes) >= 3 && isDigit(runes[0]) && isDigit(runes[1]) && isDigit(runes[2]) {
+		v, _ := strconv.Atoi(string(runes[0:3]))
+		if v > 255 {
+			return 0, 0, fmt.Errorf("the escape sequence `\\%s` is out of range", string(runes[0:3]))
+		}
+		return rune(v), 3, nil
+	}
+
+	if isDigit(runes[0]) {
+		return 0, 0, fmt.Errorf("a numeric escape sequence must contain three digits")
+	}
+
+	return runes[0], 1, nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// parseTTL parses a TTL either as a number of seconds or using BIND's unit syntax, e.g. `1h30m`
+func parseTTL(input string) (int64, error) {
+	if input == "" {
+		return 0, fmt.Errorf("the TTL cannot be empty")
+	}
+
+	if v, err := strconv.ParseInt(input, 10, 64); err == nil {
+		if v < 0 || v > 2147483647 {
+			return 0, fmt.Errorf("the TTL %q is out of range", input)
+		}
+		return v, nil
+	}
+
+	units := map[rune]int64{
+		's': 1,
+		'm': 60,
+		'h': 60 * 60,
+		'd': 24 * 60 * 60,
+		'w': 7 * 24 * 60 * 60,
+	}
+
+	total := int64(0)
+	digits := ""
+	for _, c := range strings.ToLower(input) {
+		if isDigit(c) {
+			digits += string(c)
+			continue
+		}
+
+		multiplier, ok := units[c]
+		if !ok || digits == "" {
+			return 0, fmt.Errorf("%q is not a valid TTL", input)
+		}
+
+		v, _ := strconv.ParseInt(digits, 10, 64)
+		total += v * multiplier
+		digits = ""
+	}
+
+	if digits != "" || total > 2147483647 {
+		return 0, fmt.Errorf("%q is not a valid TTL", input)
+	}
+
+	return total, nil
+}
+
+// qualify returns the fully qualified form of a name (without a trailing dot)
+func qualify(name string, origin string) string {
+	if name == "@" {
+		return origin
+	}
+
+	if strings.HasSuffix(name, ".") {
+		return strings.TrimSuffix(name, ".")
+	}
+
+	return name + "." + origin
+}
+
+// relativeName returns an owner name relative to the zone, where `@` denotes the apex
+func relativeName(name string, origin string, zone string) (string, error) {
+	fqdn := strings.ToLower(qualify(name, origin))
+
+	if fqdn == zone {
+		return "@", nil
+	}
+
+	if !strings.HasSuffix(fqdn, "."+zone) {
+		return "", fmt.Errorf("the name %q is outside of the zone %q", fqdn, zone)
+	}
+
+	return strings.TrimSuffix(fqdn, "."+zone), nil
+}
+
+func parseRecordData(recordType string, tokens []token, origin string) (string, error) {
+	expectFields := func(count int) error {
+		if len(tokens) != count {
+			return fmt.Errorf("a %s record expects %d field(s) but got %d", recordType, count, len(tokens))
+		}
+		return nil
+	}
+
+	switch recordType {
+	case "A":
+		if err := expectFields(1); err != nil {
+			return "", err
+		}
+		ip := net.ParseIP(tokens[0].value)
+		if ip == nil || ip.To4() == nil {
+			return "", fmt.Errorf("%q is not a valid IPv4 address", tokens[0].value)
+		}
+		return ip.To4().String(), nil
+
+	case "AAAA":
+		if err := expectFields(1); err != nil {
+			return "", err
+		}
+		ip := net.ParseIP(tokens[0].value)
+		if ip == nil || ip.To4() != nil {
+			return "", fmt.Errorf("%q is not a valid IPv6 address", tokens[0].value)
+		}
+		return ip.String(), nil
+
+	case "CNAME", "NS", "PTR":
+		if err := expectFields(1); err != nil {
+			return "", err
+		}
+		return qualify(tokens[0].value, origin), nil
+
+	case "MX":
+		if err := expectFields(2); err != nil {
+			return "", err
+		}
+		preference, err := parseUint16(tokens[0].value, "preference")
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d %s", preference, qualify(tokens[1].value, origin)), nil
+
+	case "SRV":
+		if err := expectFields(4); err != nil {
+			return "", err
+		}
+		values := make([]int, 0, 3)
+		for i, field := range []string{"priority", "weight", "port"} {
+			v, err := parseUint16(tokens[i].value, field)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, v)
+		}
+		return fmt.Sprintf("%d %d %d %s", values[0], values[1], values[2], qualify(tokens[3].value, origin)), nil
+
+	case "TXT":
+		if len(tokens) == 0 {
+			return "", fmt.Errorf("a TXT record expects at least one value")
+		}
+		values := make([]string, 0, len(tokens))
+		for _, t := range tokens {
+			values = append(values, Quote(t.value))
+		}
+		return strings.Join(values, " "), nil
+
+	case "CAA":
+		if err := expectFields(3); err != nil {
+			return "", err
+		}
+		flags, err := strconv.Atoi(tokens[0].value)
+		if err != nil || flags < 0 || flags > 255 {
+			return "", fmt.Errorf("the CAA flags must be a number between 0 and 255, got %q", tokens[0].value)
+		}
+		tag := strings.ToLower(tokens[1].value)
+		if tag != "issue" && tag != "issuewild" && tag != "iodef" {
+			return "", fmt.Errorf("the CAA tag must be one of `issue`, `issuewild` or `iodef`, got %q", tokens[1].value)
+		}
+		return fmt.Sprintf("%d %s %s", flags, tag, Quote(tokens[2].value)), nil
+
+	case "SOA":
+		if err := expectFields(7); err != nil {
+			return "", err
+		}
+		if _, err := strconv.ParseUint(tokens[2].value, 10, 32); err != nil {
+			return "", fmt.Errorf("the SOA serial must be a number between 0 and 4294967295, got %q", tokens[2].value)
+		}
+		for i, field := range []string{"refresh", "retry", "expire", "minimum"} {
+			if _, err := parseTTL(tokens[i+3].value); err != nil {
+				return "", fmt.Errorf("the SOA %s is invalid: %+v", field, err)
+			}
+		}
+		fields := []string{qualify(tokens[0].value, origin), qualify(tokens[1].value, origin)}
+		for _, t := range tokens[2:] {
+			fields = append(fields, t.value)
+		}
+		return strings.Join(fields, " "), nil
+	}
+
+	return "", fmt.Errorf("the record type %q is not supported", recordType)
+}
+
+func parseUint16(input string, field string) (int, error) {
+	v, err := strconv.Atoi(input)
+	if err != nil || v < 0 || v > 65535 {
+		return 0, fmt.Errorf("the %s must be a number between 0 and 65535, got %q", field, input)
+	}
+	return v, nil
+}
@@ -0,0 +1,297 @@
+package zonefile
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	input := `
+$ORIGIN example.com.
+$TTL 1h
+@       IN  SOA   ns1.example.com. hostmaster.example.com. (
+                  2019050101 ; serial
+                  1d         ; refresh
+                  2h         ; retry
+                  4w         ; expire
+                  1h )       ; minimum
+        IN  NS    ns1
+        IN  NS    ns2.example.net.
+        IN  MX    10 mail
+        IN  MX    20 mail.backup.example.net.
+@       IN  A     10.0.0.1
+www 300 IN  A     10.0.0.2
+            A     10.0.0.3
+WWW         A     10.0.0.2 ; duplicate
+ipv6    IN  AAAA  2001:0db8:0000:0000:0000:0000:0000:0001
+ftp         CNAME www
+_sip._tcp   SRV   10 60 5060 sip
+@           TXT   "v=spf1 include:spf.example.net -all"
+long        TXT   "first chunk" "with \"quotes\"" unquoted
+@           CAA   0 issue "letsencrypt.org"
+delegated   NS    ns1.delegated.example.com.
+
+$ORIGIN sub.example.com.
+host    7200    A     10.0.1.1
+*               A     10.0.1.2
+1.0             PTR   host
+`
+
+	expected := []RecordSet{
+		{Name: "*.sub", Type: "A", TTL: 3600, Records: []string{"10.0.1.2"}},
+		{Name: "1.0.sub", Type: "PTR", TTL: 3600, Records: []string{"host.sub.example.com"}},
+		{Name: "@", Type: "A", TTL: 3600, Records: []string{"10.0.0.1"}},
+		{Name: "@", Type: "CAA", TTL: 3600, Records: []string{`0 issue "letsencrypt.org"`}},
+		{Name: "@", Type: "MX", TTL: 3600, Records: []string{"10 mail.example.com", "20 mail.backup.example.net"}},
+		{Name: "@", Type: "NS", TTL: 3600, Records: []string{"ns1.example.com", "ns2.example.net"}},
+		{Name: "@", Type: "SOA", TTL: 3600, Records: []string{"ns1.example.com hostmaster.example.com 2019050101 1d 2h 4w 1h"}},
+		{Name: "@", Type: "TXT", TTL: 3600, Records: []string{`"v=spf1 include:spf.example.net -all"`}},
+		{Name: "_sip._tcp", Type: "SRV", TTL: 3600, Records: []string{"10 60 5060 sip.example.com"}},
+		{Name: "delegated", Type: "NS", TTL: 3600, Records: []string{"ns1.delegated.example.com"}},
+		{Name: "ftp", Type: "CNAME", TTL: 3600, Records: []string{"www.example.com"}},
+		{Name: "host.sub", Type: "A", TTL: 7200, Records: []string{"10.0.1.1"}},
+		{Name: "ipv6", Type: "AAAA", TTL: 3600, Records: []string{"2001:db8::1"}},
+		{Name: "long", Type: "TXT", TTL: 3600, Records: []string{`"first chunk" "with \"quotes\"" "unquoted"`}},
+		{Name: "www", Type: "A", TTL: 300, Records: []string{"10.0.0.2", "10.0.0.3"}},
+	}
+
+	actual, err := Parse(input, "example.com", 300)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("Expected:\n%+v\n\nGot:\n%+v", expected, actual)
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Input    string
+		Expected []RecordSet
+	}{
+		{
+			Name:  "Default TTL and Origin",
+			Input: "www A 10.0.0.1",
+			Expected: []RecordSet{
+				{Name: "www", Type: "A", TTL: 300, Records: []string{"10.0.0.1"}},
+			},
+		},
+		{
+			Name:  "Class before TTL",
+			Input: "www IN 60 A 10.0.0.1",
+			Expected: []RecordSet{
+				{Name: "www", Type: "A", TTL: 60, Records: []string{"10.0.0.1"}},
+			},
+		},
+		{
+			Name:  "Lowest TTL wins",
+			Input: "www 600 A 10.0.0.1\nwww 60 A 10.0.0.2",
+			Expected: []RecordSet{
+				{Name: "www", Type: "A", TTL: 60, Records: []string{"10.0.0.1", "10.0.0.2"}},
+			},
+		},
+		{
+			Name:  "Absolute Name",
+			Input: "www.example.com. A 10.0.0.1",
+			Expected: []RecordSet{
+				{Name: "www", Type: "A", TTL: 300, Records: []string{"10.0.0.1"}},
+			},
+		},
+		{
+			Name:  "Relative Origin",
+			Input: "$ORIGIN sub\nwww A 10.0.0.1",
+			Expected: []RecordSet{
+				{Name: "www.sub", Type: "A", TTL: 300, Records: []string{"10.0.0.1"}},
+			},
+		},
+		{
+			Name:  "Windows Line Endings",
+			Input: "www A 10.0.0.1\r\n    A 10.0.0.2\r\n",
+			Expected: []RecordSet{
+				{Name: "www", Type: "A", TTL: 300, Records: []string{"10.0.0.1", "10.0.0.2"}},
+			},
+		},
+		{
+			Name:  "Decimal Escape",
+			Input: `txt TXT "semi\059colon"`,
+			Expected: []RecordSet{
+				{Name: "txt", Type: "TXT", TTL: 300, Records: []string{`"semi;colon"`}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := Parse(tc.Input, "example.com.", 300)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+
+			if !reflect.DeepEqual(tc.Expected, actual) {
+				t.Fatalf("Expected %+v but got %+v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Input string
+	}{
+		{
+			Name:  "Outside of Zone",
+			Input: "www.example.net. A 10.0.0.1",
+		},
+		{
+			Name:  "Blank Owner without Previous",
+			Input: "    A 10.0.0.1",
+		},
+		{
+			Name:  "Unsupported Directive",
+			Input: "$INCLUDE other.zone",
+		},
+		{
+			Name:  "Unsupported Class",
+			Input: "www CH A 10.0.0.1",
+		},
+		{
+			Name:  "Unsupported Type",
+			Input: "www HINFO cpu os",
+		},
+		{
+			Name:  "Invalid IPv4",
+			Input: "www A 2001:db8::1",
+		},
+		{
+			Name:  "Invalid IPv6",
+			Input: "www AAAA 10.0.0.1",
+		},
+		{
+			Name:  "Invalid MX Preference",
+			Input: "@ MX 70000 mail",
+		},
+		{
+			Name:  "Invalid CAA Tag",
+			Input: `@ CAA 0 unknown "value"`,
+		},
+		{
+			Name:  "Unbalanced Parentheses",
+			Input: "www A ( 10.0.0.1",
+		},
+		{
+			Name:  "Unterminated Quote",
+			Input: `txt TXT "value`,
+		},
+		{
+			Name:  "Multiple CNAMEs",
+			Input: "ftp CNAME one\nftp CNAME two",
+		},
+		{
+			Name:  "CNAME and other Records",
+			Input: "ftp CNAME www\nftp A 10.0.0.1",
+		},
+		{
+			Name:  "SOA outside of Apex",
+			Input: "www SOA ns1 hostmaster 1 1 1 1 1",
+		},
+		{
+			Name:  "Invalid TTL",
+			Input: "$TTL 1x",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if _, err := Parse(tc.Input, "example.com", 300); err == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			}
+		})
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected int64
+		Error    bool
+	}{
+		{Input: "3600", Expected: 3600},
+		{Input: "1h", Expected: 3600},
+		{Input: "1H30M", Expected: 5400},
+		{Input: "1w2d", Expected: 777600},
+		{Input: "", Error: true},
+		{Input: "h", Error: true},
+		{Input: "10", Expected: 10},
+		{Input: "10x", Error: true},
+		{Input: "1h5", Error: true},
+		{Input: "-1", Error: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Input, func(t *testing.T) {
+			actual, err := parseTTL(tc.Input)
+			if tc.Error {
+				if err == nil {
+					t.Fatalf("Expected an error but didn't get one")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+
+			if actual != tc.Expected {
+				t.Fatalf("Expected %d but got %d", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestSplitRecordData(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected []string
+	}{
+		{Input: "10 mail.example.com", Expected: []string{"10", "mail.example.com"}},
+		{Input: `0 issue "letsencrypt.org"`, Expected: []string{"0", "issue", "letsencrypt.org"}},
+		{Input: `"first chunk" "with \"quotes\""`, Expected: []string{"first chunk", `with "quotes"`}},
+		{Input: Quote(`back\slash`), Expected: []string{`back\slash`}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Input, func(t *testing.T) {
+			actual, err := SplitRecordData(tc.Input)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+
+			if !reflect.DeepEqual(tc.Expected, actual) {
+				t.Fatalf("Expected %+v but got %+v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestRecordSetIsZoneAuthority(t *testing.T) {
+	cases := []struct {
+		RecordSet RecordSet
+		Expected  bool
+	}{
+		{RecordSet: RecordSet{Name: "@", Type: "SOA"}, Expected: true},
+		{RecordSet: RecordSet{Name: "@", Type: "NS"}, Expected: true},
+		{RecordSet: RecordSet{Name: "@", Type: "A"}, Expected: false},
+		{RecordSet: RecordSet{Name: "delegated", Type: "NS"}, Expected: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.RecordSet.Key(), func(t *testing.T) {
+			if actual := tc.RecordSet.IsZoneAuthority(); actual != tc.Expected {
+				t.Fatalf("Expected %t but got %t", tc.Expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// loadBalancerBatchWindow is how long the first change to a Load Balancer waits for changes
+// from other child resources (rules, probes, pools etc.) before the Load Balancer is updated
+const loadBalancerBatchWindow = 2 * time.Second
+
+// loadBalancerMutation applies the change for a single child resource to the Load Balancer.
+// It must only return an error before it has modified the Load Balancer, since the other
+// mutations in the same batch are still sent to the API. Where the batched update fails the
+// mutation may be applied again to a freshly retrieved Load Balancer.
+type loadBalancerMutation func(lb *network.LoadBalancer) error
+
+// loadBalancerUpdater is the subset of the Load Balancers API the batcher needs, allowing
+// it to be tested without Azure
+type loadBalancerUpdater interface {
+	get(ctx context.Context, resourceGroup string, name string) (*network.LoadBalancer, bool, error)
+	createOrUpdate(ctx context.Context, resourceGroup string, name string, lb network.LoadBalancer) (*network.LoadBalancer, error)
+}
+
+// loadBalancerBatcher coalesces the changes child resources make to the same Load Balancer,
+// so that they're applied with a single GET-modify-PUT rather than one per child resource.
+type loadBalancerBatcher struct {
+	updater loadBalancerUpdater
+	window  time.Duration
+
+	lock    sync.Mutex
+	pending map[string]*loadBalancerBatch
+}
+
+type loadBalancerBatch struct {
+	ctx      context.Context
+	requests []*loadBalancerBatchRequest
+}
+
+type loadBalancerBatchRequest struct {
+	mutate loadBalancerMutation
+	result chan loadBalancerBatchResult
+}
+
+type loadBalancerBatchResult struct {
+	loadBalancer *network.LoadBalancer
+	exists       bool
+	err          error
+}
+
+func newLoadBalancerBatcher(updater loadBalancerUpdater, window time.Duration) *loadBalancerBatcher {
+	return &loadBalancerBatcher{
+		updater: updater,
+		window:  window,
+		pending: make(map[string]*loadBalancerBatch),
+	}
+}
+
+// update queues the mutation against the Load Balancer and blocks until the batch containing it
+// has been applied. It returns the Load Balancer as read back after the update, or false if the
+// Load Balancer doesn't exist. Mutations are applied in the order they were queued.
+func (b *loadBalancerBatcher) update(ctx context.Context, loadBalancerId string, mutate loadBalancerMutation) (*network.LoadBalancer, bool, error) {
+	request := &loadBalancerBatchRequest{
+		mutate: mutate,
+		result: make(chan loadBalancerBatchResult, 1),
+	}
+
+	b.lock.Lock()
+	batch, ok := b.pending[loadBalancerId]
+	if !ok {
+		batch = &loadBalancerBatch{
+			ctx: ctx,
+		}
+		b.pending[loadBalancerId] = batch
+		go b.process(loadBalancerId, batch)
+	}
+	batch.requests = append(batch.requests, request)
+	b.lock.Unlock()
+
+	result := <-request.result
+	return result.loadBalancer, result.exists, result.err
+}
+
+func (b *loadBalancerBatcher) process(loadBalancerId string, batch *loadBalancerBatch) {
+	time.Sleep(b.window)
+
+	// only one batch per Load Balancer can be in flight - changes arriving in the meantime
+	// continue to join this batch until the previous one has completed
+	armMutexKV.Lock(loadBalancerId)
+	defer armMutexKV.Unlock(loadBalancerId)
+
+	b.lock.Lock()
+	delete(b.pending, loadBalancerId)
+	requests := batch.requests
+	b.lock.Unlock()
+
+	results := b.apply(batch.ctx, loadBalancerId, requests)
+	for i, request := range requests {
+		request.result <- results[i]
+	}
+}
+
+func (b *loadBalancerBatcher) apply(ctx context.Context, loadBalancerId string, requests []*loadBalancerBatchRequest) []loadBalancerBatchResult {
+	results := make([]loadBalancerBatchResult, len(requests))
+	failAll := func(err error) []loadBalancerBatchResult {
+		for i := range results {
+			results[i].err = err
+		}
+		return results
+	}
+
+	resGroup, name, err := resourceGroupAndLBNameFromId(loadBalancerId)
+	if err != nil {
+		return failAll(fmt.Errorf("Error Getting Load Balancer Name and Group: %+v", err))
+	}
+
+	loadBalancer, exists, err := b.updater.get(ctx, resGroup, name)
+	if err != nil {
+		return failAll(fmt.Errorf("Error Getting Load Balancer By ID: %+v", err))
+	}
+	if !exists {
+		return results
+	}
+
+	applied := 0
+	for i, request := range requests {
+		if err := request.mutate(loadBalancer); err != nil {
+			results[i].err = err
+			continue
+		}
+		applied++
+	}
+
+	if applied == 0 {
+		return results
+	}
+
+	log.Printf("[DEBUG] Updating Load Balancer %q (Resource Group %q) with %d change(s)", name, resGroup, applied)
+	read, err := b.updater.createOrUpdate(ctx, resGroup, name, *loadBalancer)
+	if err != nil && applied > 1 {
+		// the API rejects the whole Load Balancer, so we can't tell which change caused the error - instead
+		// retry each change on its own, so that each child resource gets the error for its own change
+		log.Printf("[DEBUG] Updating Load Balancer %q (Resource Group %q) with %d changes failed - retrying each change individually: %+v", name, resGroup, applied, err)
+		for i, request := range requests {
+			if results[i].err != nil {
+				continue
+			}
+
+			results[i] = b.apply(ctx, loadBalancerId, []*loadBalancerBatchRequest{request})[0]
+		}
+		return results
+	}
+
+	for i := range results {
+		if results[i].err != nil {
+			continue
+		}
+
+		if err != nil {
+			results[i].err = err
+			continue
+		}
+
+		results[i].loadBalancer = read
+		results[i].exists = true
+	}
+
+	return results
+}
+
+// armLoadBalancerUpdater is the loadBalancerUpdater backed by the Azure API
+type armLoadBalancerUpdater struct {
+	client network.LoadBalancersClient
+}
+
+func (u armLoadBalancerUpdater) get(ctx context.Context, resourceGroup string, name string) (*network.LoadBalancer, bool, error) {
+	resp, err := u.client.Get(ctx, resourceGroup, name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("Error making Read request on Azure Load Balancer %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	return &resp, true, nil
+}
+
+func (u armLoadBalancerUpdater) createOrUpdate(ctx context.Context, resourceGroup string, name string, lb network.LoadBalancer) (*network.LoadBalancer, error) {
+	future, err := u.client.CreateOrUpdate(ctx, resourceGroup, name, lb)
+	if err != nil {
+		return nil, fmt.Errorf("Error Creating/Updating Load Balancer %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, u.client.Client); err != nil {
+		return nil, fmt.Errorf("Error waiting for completion of Load Balancer %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	read, err := u.client.Get(ctx, resourceGroup, name, "")
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving Load Balancer %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	if read.ID == nil {
+		return nil, fmt.Errorf("Cannot read Load Balancer %q (Resource Group %q) ID", name, resourceGroup)
+	}
+
+	return &read, nil
+}
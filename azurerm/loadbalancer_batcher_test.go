@@ -0,0 +1,395 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+const testLoadBalancerBatcherId = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Network/loadBalancers/lb%d"
+
+// fakeLoadBalancerUpdater stores a single Load Balancer in memory, handing out copies so that
+// mutations only take effect once they've been PUT
+type fakeLoadBalancerUpdater struct {
+	lock         sync.Mutex
+	loadBalancer *network.LoadBalancer
+	putError     error
+	rejectProbe  string
+	puts         int
+	inFlight     int
+	maxInFlight  int
+}
+
+func newFakeLoadBalancerUpdater(id string) *fakeLoadBalancerUpdater {
+	return &fakeLoadBalancerUpdater{
+		loadBalancer: &network.LoadBalancer{
+			ID: utils.String(id),
+			LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+				Probes: &[]network.Probe{},
+			},
+		},
+	}
+}
+
+func (f *fakeLoadBalancerUpdater) copy() *network.LoadBalancer {
+	probes := append([]network.Probe{}, *f.loadBalancer.LoadBalancerPropertiesFormat.Probes...)
+	return &network.LoadBalancer{
+		ID: f.loadBalancer.ID,
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			Probes: &probes,
+		},
+	}
+}
+
+func (f *fakeLoadBalancerUpdater) get(_ context.Context, _ string, _ string) (*network.LoadBalancer, bool, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.loadBalancer == nil {
+		return nil, false, nil
+	}
+
+	return f.copy(), true, nil
+}
+
+func (f *fakeLoadBalancerUpdater) createOrUpdate(_ context.Context, _ string, name string, lb network.LoadBalancer) (*network.LoadBalancer, error) {
+	f.lock.Lock()
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	f.lock.Unlock()
+
+	// long running operation
+	time.Sleep(20 * time.Millisecond)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.inFlight--
+	f.puts++
+
+	if f.putError != nil {
+		return nil, f.putError
+	}
+
+	if f.rejectProbe != "" {
+		if _, _, exists := findLoadBalancerProbeByName(&lb, f.rejectProbe); exists {
+			return nil, fmt.Errorf("probe %q is invalid", f.rejectProbe)
+		}
+	}
+
+	probes := make([]network.Probe, 0)
+	for _, probe := range *lb.LoadBalancerPropertiesFormat.Probes {
+		probe.ID = utils.String(fmt.Sprintf("%s/probes/%s", *f.loadBalancer.ID, *probe.Name))
+		probes = append(probes, probe)
+	}
+	f.loadBalancer.LoadBalancerPropertiesFormat.Probes = &probes
+
+	return f.copy(), nil
+}
+
+func (f *fakeLoadBalancerUpdater) probe(name string) *network.Probe {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, probe := range *f.loadBalancer.LoadBalancerPropertiesFormat.Probes {
+		if *probe.Name == name {
+			return &probe
+		}
+	}
+
+	return nil
+}
+
+// these mirror the mutations made by azurerm_lb_probe
+func testLoadBalancerBatcherUpsertProbe(name string, port int32) loadBalancerMutation {
+	return func(lb *network.LoadBalancer) error {
+		probes := append(*lb.LoadBalancerPropertiesFormat.Probes, network.Probe{
+			Name: utils.String(name),
+			ProbePropertiesFormat: &network.ProbePropertiesFormat{
+				Port: utils.Int32(port),
+			},
+		})
+
+		if _, index, exists := findLoadBalancerProbeByName(lb, name); exists {
+			probes = append(probes[:index], probes[index+1:]...)
+		}
+
+		lb.LoadBalancerPropertiesFormat.Probes = &probes
+		return nil
+	}
+}
+
+func testLoadBalancerBatcherDeleteProbe(name string) loadBalancerMutation {
+	return func(lb *network.LoadBalancer) error {
+		_, index, exists := findLoadBalancerProbeByName(lb, name)
+		if !exists {
+			return nil
+		}
+
+		probes := *lb.LoadBalancerPropertiesFormat.Probes
+		probes = append(probes[:index], probes[index+1:]...)
+		lb.LoadBalancerPropertiesFormat.Probes = &probes
+		return nil
+	}
+}
+
+func TestLoadBalancerBatcher_coalescesConcurrentChildren(t *testing.T) {
+	id := fmt.Sprintf(testLoadBalancerBatcherId, 1)
+	fake := newFakeLoadBalancerUpdater(id)
+	batcher := newLoadBalancerBatcher(fake, 100*time.Millisecond)
+
+	children := 40
+	errors := make([]error, children)
+	ids := make([]string, children)
+
+	var wg sync.WaitGroup
+	for i := 0; i < children; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			name := fmt.Sprintf("probe%d", i)
+			read, exists, err := batcher.update(context.Background(), id, testLoadBalancerBatcherUpsertProbe(name, int32(i)))
+			if err != nil {
+				errors[i] = err
+				return
+			}
+			if !exists {
+				errors[i] = fmt.Errorf("expected the Load Balancer to exist")
+				return
+			}
+
+			probe, _, ok := findLoadBalancerProbeByName(read, name)
+			if !ok || probe.ID == nil {
+				errors[i] = fmt.Errorf("expected %q to have been created", name)
+				return
+			}
+			ids[i] = *probe.ID
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errors {
+		if err != nil {
+			t.Fatalf("Child %d: %+v", i, err)
+		}
+		if expected := fmt.Sprintf("%s/probes/probe%d", id, i); ids[i] != expected {
+			t.Fatalf("Child %d: expected ID %q but got %q", i, expected, ids[i])
+		}
+	}
+
+	if actual := len(*fake.loadBalancer.LoadBalancerPropertiesFormat.Probes); actual != children {
+		t.Fatalf("Expected %d probes but got %d", children, actual)
+	}
+
+	if fake.puts >= children {
+		t.Fatalf("Expected the %d changes to be batched but got %d PUTs", children, fake.puts)
+	}
+
+	if fake.maxInFlight != 1 {
+		t.Fatalf("Expected a single PUT in flight at once but got %d", fake.maxInFlight)
+	}
+}
+
+func TestLoadBalancerBatcher_preservesOrderPerChild(t *testing.T) {
+	id := fmt.Sprintf(testLoadBalancerBatcherId, 2)
+	fake := newFakeLoadBalancerUpdater(id)
+	batcher := newLoadBalancerBatcher(fake, 5*time.Millisecond)
+
+	children := 10
+	var wg sync.WaitGroup
+	for i := 0; i < children; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// each child's changes are sequential, as they are in Terraform, but interleave with the other children's
+			name := fmt.Sprintf("probe%d", i)
+			steps := []loadBalancerMutation{
+				testLoadBalancerBatcherUpsertProbe(name, 80),
+				testLoadBalancerBatcherUpsertProbe(name, 81),
+				testLoadBalancerBatcherDeleteProbe(name),
+				testLoadBalancerBatcherUpsertProbe(name, int32(1000+i)),
+			}
+			for _, step := range steps {
+				if _, _, err := batcher.update(context.Background(), id, step); err != nil {
+					t.Errorf("Child %d: %+v", i, err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < children; i++ {
+		probe := fake.probe(fmt.Sprintf("probe%d", i))
+		if probe == nil {
+			t.Fatalf("Expected probe%d to exist", i)
+		}
+		if port := *probe.ProbePropertiesFormat.Port; port != int32(1000+i) {
+			t.Fatalf("Expected probe%d to have port %d but got %d", i, 1000+i, port)
+		}
+	}
+}
+
+func TestLoadBalancerBatcher_appliesMutationsInArrivalOrder(t *testing.T) {
+	id := fmt.Sprintf(testLoadBalancerBatcherId, 3)
+	fake := newFakeLoadBalancerUpdater(id)
+	batcher := newLoadBalancerBatcher(fake, 200*time.Millisecond)
+
+	var lock sync.Mutex
+	order := make([]int, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			batcher.update(context.Background(), id, func(lb *network.LoadBalancer) error {
+				lock.Lock()
+				order = append(order, i)
+				lock.Unlock()
+				return testLoadBalancerBatcherUpsertProbe("shared", int32(i))(lb)
+			})
+		}(i)
+
+		// ensure each change is queued before the next
+		time.Sleep(10 * time.Millisecond)
+	}
+	wg.Wait()
+
+	for i, v := range order {
+		if v != i {
+			t.Fatalf("Expected the mutations to be applied in order but got %v", order)
+		}
+	}
+
+	if port := *fake.probe("shared").ProbePropertiesFormat.Port; port != 4 {
+		t.Fatalf("Expected the last change to win with port 4 but got %d", port)
+	}
+
+	if fake.puts != 1 {
+		t.Fatalf("Expected a single PUT but got %d", fake.puts)
+	}
+}
+
+func TestLoadBalancerBatcher_failedMutationIsIsolated(t *testing.T) {
+	id := fmt.Sprintf(testLoadBalancerBatcherId, 4)
+	fake := newFakeLoadBalancerUpdater(id)
+	batcher := newLoadBalancerBatcher(fake, 50*time.Millisecond)
+
+	var wg sync.WaitGroup
+	var goodErr, badErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _, goodErr = batcher.update(context.Background(), id, testLoadBalancerBatcherUpsertProbe("good", 80))
+	}()
+	go func() {
+		defer wg.Done()
+		_, _, badErr = batcher.update(context.Background(), id, func(lb *network.LoadBalancer) error {
+			return fmt.Errorf("expanding failed")
+		})
+	}()
+	wg.Wait()
+
+	if goodErr != nil {
+		t.Fatalf("Expected no error for the valid change but got %+v", goodErr)
+	}
+	if badErr == nil {
+		t.Fatalf("Expected an error for the invalid change")
+	}
+	if fake.probe("good") == nil {
+		t.Fatalf("Expected the valid change to have been applied")
+	}
+}
+
+func TestLoadBalancerBatcher_putErrorIsReturnedToEveryChild(t *testing.T) {
+	id := fmt.Sprintf(testLoadBalancerBatcherId, 5)
+	fake := newFakeLoadBalancerUpdater(id)
+	fake.putError = fmt.Errorf("conflict")
+	batcher := newLoadBalancerBatcher(fake, 50*time.Millisecond)
+
+	errors := make([]error, 3)
+	var wg sync.WaitGroup
+	for i := range errors {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, errors[i] = batcher.update(context.Background(), id, testLoadBalancerBatcherUpsertProbe(fmt.Sprintf("probe%d", i), 80))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errors {
+		if err == nil {
+			t.Fatalf("Expected child %d to receive the PUT error", i)
+		}
+	}
+
+	if len(*fake.loadBalancer.LoadBalancerPropertiesFormat.Probes) != 0 {
+		t.Fatalf("Expected no probes to have been stored")
+	}
+}
+
+func TestLoadBalancerBatcher_putErrorIsReturnedToTheRejectedChild(t *testing.T) {
+	id := fmt.Sprintf(testLoadBalancerBatcherId, 7)
+	fake := newFakeLoadBalancerUpdater(id)
+	fake.rejectProbe = "probe2"
+	batcher := newLoadBalancerBatcher(fake, 50*time.Millisecond)
+
+	errors := make([]error, 5)
+	var wg sync.WaitGroup
+	for i := range errors {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, errors[i] = batcher.update(context.Background(), id, testLoadBalancerBatcherUpsertProbe(fmt.Sprintf("probe%d", i), 80))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errors {
+		name := fmt.Sprintf("probe%d", i)
+		if name == fake.rejectProbe {
+			if err == nil {
+				t.Fatalf("Expected child %d to receive the PUT error", i)
+			}
+			if fake.probe(name) != nil {
+				t.Fatalf("Expected %q not to have been stored", name)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Expected no error for child %d but got %+v", i, err)
+		}
+		if fake.probe(name) == nil {
+			t.Fatalf("Expected %q to have been stored", name)
+		}
+	}
+}
+
+func TestLoadBalancerBatcher_loadBalancerNotFound(t *testing.T) {
+	id := fmt.Sprintf(testLoadBalancerBatcherId, 6)
+	fake := newFakeLoadBalancerUpdater(id)
+	fake.loadBalancer = nil
+	batcher := newLoadBalancerBatcher(fake, time.Millisecond)
+
+	read, exists, err := batcher.update(context.Background(), id, testLoadBalancerBatcherUpsertProbe("probe", 80))
+	if err != nil {
+		t.Fatalf("Expected no error but got %+v", err)
+	}
+	if exists || read != nil {
+		t.Fatalf("Expected the Load Balancer not to exist")
+	}
+	if fake.puts != 0 {
+		t.Fatalf("Expected no PUTs but got %d", fake.puts)
+	}
+}
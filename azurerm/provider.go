@@ -164,6 +164,7 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_traffic_manager_geographical_location":  dataSourceArmTrafficManagerGeographicalLocation(),
 			"azurerm_virtual_machine":                        dataSourceArmVirtualMachine(),
 			"azurerm_virtual_network_gateway":                dataSourceArmVirtualNetworkGateway(),
+			"azurerm_virtual_network_gateway_bgp_status":     dataSourceArmVirtualNetworkGatewayBgpStatus(),
 			"azurerm_virtual_network":                        dataSourceArmVirtualNetwork(),
 		},
 
@@ -238,6 +239,7 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_dns_srv_record":                         resourceArmDnsSrvRecord(),
 			"azurerm_dns_txt_record":                         resourceArmDnsTxtRecord(),
 			"azurerm_dns_zone":                               resourceArmDnsZone(),
+			"azurerm_dns_zone_records":                       resourceArmDnsZoneRecords(),
 			"azurerm_eventgrid_domain":                       resourceArmEventGridDomain(),
 			"azurerm_eventgrid_event_subscription":           resourceArmEventGridEventSubscription(),
 			"azurerm_eventgrid_topic":                        resourceArmEventGridTopic(),
@@ -249,7 +251,9 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_express_route_circuit_authorization":    resourceArmExpressRouteCircuitAuthorization(),
 			"azurerm_express_route_circuit_peering":          resourceArmExpressRouteCircuitPeering(),
 			"azurerm_express_route_circuit":                  resourceArmExpressRouteCircuit(),
+			"azurerm_express_route_port":                     resourceArmExpressRoutePort(),
 			"azurerm_firewall_application_rule_collection":   resourceArmFirewallApplicationRuleCollection(),
+			"azurerm_firewall_nat_rule_collection":           resourceArmFirewallNatRuleCollection(),
 			"azurerm_firewall_network_rule_collection":       resourceArmFirewallNetworkRuleCollection(),
 			"azurerm_firewall":                               resourceArmFirewall(),
 			"azurerm_function_app":                           resourceArmFunctionApp(),
@@ -262,6 +266,7 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_key_vault_secret":                       resourceArmKeyVaultSecret(),
 			"azurerm_key_vault":                              resourceArmKeyVault(),
 			"azurerm_kubernetes_cluster":                     resourceArmKubernetesCluster(),
+			"azurerm_kubernetes_cluster_node_pool":           resourceArmKubernetesClusterNodePool(),
 			"azurerm_lb_backend_address_pool":                resourceArmLoadBalancerBackendAddressPool(),
 			"azurerm_lb_nat_pool":                            resourceArmLoadBalancerNatPool(),
 			"azurerm_lb_nat_rule":                            resourceArmLoadBalancerNatRule(),
@@ -308,6 +313,7 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_network_security_group":                                                 resourceArmNetworkSecurityGroup(),
 			"azurerm_network_security_rule":                                                  resourceArmNetworkSecurityRule(),
 			"azurerm_network_watcher":                                                        resourceArmNetworkWatcher(),
+			"azurerm_network_watcher_flow_log":                                               resourceArmNetworkWatcherFlowLog(),
 			"azurerm_notification_hub_authorization_rule":                                    resourceArmNotificationHubAuthorizationRule(),
 			"azurerm_notification_hub_namespace":                                             resourceArmNotificationHubNamespace(),
 			"azurerm_notification_hub":                                                       resourceArmNotificationHub(),
@@ -320,7 +326,17 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_postgresql_firewall_rule":                                               resourceArmPostgreSQLFirewallRule(),
 			"azurerm_postgresql_server":                                                      resourceArmPostgreSQLServer(),
 			"azurerm_postgresql_virtual_network_rule":                                        resourceArmPostgreSQLVirtualNetworkRule(),
+			"azurerm_private_dns_a_record":                                                   resourceArmPrivateDnsARecord(),
+			"azurerm_private_dns_aaaa_record":                                                resourceArmPrivateDnsAaaaRecord(),
+			"azurerm_private_dns_cname_record":                                               resourceArmPrivateDnsCnameRecord(),
+			"azurerm_private_dns_mx_record":                                                  resourceArmPrivateDnsMxRecord(),
+			"azurerm_private_dns_ptr_record":                                                 resourceArmPrivateDnsPtrRecord(),
+			"azurerm_private_dns_srv_record":                                                 resourceArmPrivateDnsSrvRecord(),
+			"azurerm_private_dns_txt_record":                                                 resourceArmPrivateDnsTxtRecord(),
+			"azurerm_private_dns_zone":                                                       resourceArmPrivateDnsZone(),
+			"azurerm_private_dns_zone_virtual_network_link":                                  resourceArmPrivateDnsZoneVirtualNetworkLink(),
 			"azurerm_public_ip":                                                              resourceArmPublicIp(),
+			"azurerm_public_ip_prefix":                                                       resourceArmPublicIpPrefix(),
 			"azurerm_recovery_services_protected_vm":                                         resourceArmRecoveryServicesProtectedVm(),
 			"azurerm_recovery_services_protection_policy_vm":                                 resourceArmRecoveryServicesProtectionPolicyVm(),
 			"azurerm_recovery_services_vault":                                                resourceArmRecoveryServicesVault(),
@@ -330,6 +346,7 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_resource_group":                                                         resourceArmResourceGroup(),
 			"azurerm_role_assignment":                                                        resourceArmRoleAssignment(),
 			"azurerm_role_definition":                                                        resourceArmRoleDefinition(),
+			"azurerm_route_filter":                                                           resourceArmRouteFilter(),
 			"azurerm_route_table":                                                            resourceArmRouteTable(),
 			"azurerm_route":                                                                  resourceArmRoute(),
 			"azurerm_scheduler_job_collection":                                               resourceArmSchedulerJobCollection(),
@@ -378,7 +395,12 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_virtual_network_gateway_connection":                                     resourceArmVirtualNetworkGatewayConnection(),
 			"azurerm_virtual_network_gateway":                                                resourceArmVirtualNetworkGateway(),
 			"azurerm_virtual_network_peering":                                                resourceArmVirtualNetworkPeering(),
+			"azurerm_virtual_hub":                                                            resourceArmVirtualHub(),
 			"azurerm_virtual_network":                                                        resourceArmVirtualNetwork(),
+			"azurerm_virtual_wan":                                                            resourceArmVirtualWan(),
+			"azurerm_vpn_gateway":                                                            resourceArmVpnGateway(),
+			"azurerm_vpn_site":                                                               resourceArmVpnSite(),
+			"azurerm_web_application_firewall_policy":                                        resourceArmWebApplicationFirewallPolicy(),
 		},
 	}
 
@@ -419,6 +441,10 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 			return nil, err
 		}
 
+		// the Builder tries a Client Certificate first, and only falls back to MSI when no Service Principal is configured
+		client.usingClientCertificate = builder.ClientCertPath != ""
+		client.usingManagedServiceIdentity = builder.SupportsManagedServiceIdentity && !config.AuthenticatedAsAServicePrincipal
+
 		client.StopContext = p.StopContext()
 
 		// replaces the context between tests
@@ -3,6 +3,7 @@ package azurerm
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -458,6 +459,12 @@ func resourceArmApplicationGateway() *schema.Resource {
 							ValidateFunc: validate.NoEmptyStrings,
 						},
 
+						"rewrite_rule_set_name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
 						"backend_address_pool_id": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -487,6 +494,11 @@ func resourceArmApplicationGateway() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+
+						"rewrite_rule_set_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -583,7 +595,7 @@ func resourceArmApplicationGateway() *schema.Resource {
 
 						"capacity": {
 							Type:         schema.TypeInt,
-							Required:     true,
+							Optional:     true,
 							ValidateFunc: validation.IntBetween(1, 10),
 						},
 					},
@@ -615,6 +627,27 @@ func resourceArmApplicationGateway() *schema.Resource {
 				},
 			},
 
+			"autoscale_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"min_capacity": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 100),
+						},
+
+						"max_capacity": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(2, 125),
+						},
+					},
+				},
+			},
+
 			// TODO: @tombuildsstuff deprecate this in favour of a full `ssl_protocol` block in the future
 			"disabled_ssl_protocols": {
 				Type:     schema.TypeList,
@@ -635,6 +668,41 @@ func resourceArmApplicationGateway() *schema.Resource {
 				Optional: true,
 			},
 
+			"firewall_policy_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"identity": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  string(network.ResourceIdentityTypeUserAssigned),
+							ValidateFunc: validation.StringInSlice([]string{
+								string(network.ResourceIdentityTypeUserAssigned),
+							}, false),
+						},
+
+						"identity_ids": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							MaxItems: 1,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: azure.ValidateResourceID,
+							},
+						},
+					},
+				},
+			},
+
 			"probe": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -724,6 +792,113 @@ func resourceArmApplicationGateway() *schema.Resource {
 				},
 			},
 
+			"rewrite_rule_set": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"rewrite_rule": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validate.NoEmptyStrings,
+									},
+
+									"rule_sequence": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(1, 1000),
+									},
+
+									"condition": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"variable": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validate.NoEmptyStrings,
+												},
+
+												"pattern": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validate.NoEmptyStrings,
+												},
+
+												"ignore_case": {
+													Type:     schema.TypeBool,
+													Optional: true,
+												},
+
+												"negate": {
+													Type:     schema.TypeBool,
+													Optional: true,
+												},
+											},
+										},
+									},
+
+									"request_header_configuration": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"header_name": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validate.NoEmptyStrings,
+												},
+
+												"header_value": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+											},
+										},
+									},
+
+									"response_header_configuration": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"header_name": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validate.NoEmptyStrings,
+												},
+
+												"header_value": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
 			"ssl_certificate": {
 				// TODO: should this become a Set?
 				Type:     schema.TypeList,
@@ -737,17 +912,23 @@ func resourceArmApplicationGateway() *schema.Resource {
 
 						"data": {
 							Type:      schema.TypeString,
-							Required:  true,
+							Optional:  true,
 							Sensitive: true,
 							StateFunc: base64EncodedStateFunc,
 						},
 
 						"password": {
 							Type:      schema.TypeString,
-							Required:  true,
+							Optional:  true,
 							Sensitive: true,
 						},
 
+						"key_vault_secret_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
 						"id": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -821,6 +1002,12 @@ func resourceArmApplicationGateway() *schema.Resource {
 										ValidateFunc: validate.NoEmptyStrings,
 									},
 
+									"rewrite_rule_set_name": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validate.NoEmptyStrings,
+									},
+
 									"backend_address_pool_id": {
 										Type:     schema.TypeString,
 										Computed: true,
@@ -836,6 +1023,11 @@ func resourceArmApplicationGateway() *schema.Resource {
 										Computed: true,
 									},
 
+									"rewrite_rule_set_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
 									"id": {
 										Type:     schema.TypeString,
 										Computed: true,
@@ -919,6 +1111,63 @@ func resourceArmApplicationGateway() *schema.Resource {
 							ValidateFunc: validation.IntBetween(1, 128),
 							Default:      128,
 						},
+						"disabled_rule_group": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"rule_group_name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validate.NoEmptyStrings,
+									},
+
+									"rules": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeInt,
+											ValidateFunc: validation.IntAtLeast(1),
+										},
+									},
+								},
+							},
+						},
+						"exclusion": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"match_variable": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											"RequestArgNames",
+											"RequestCookieNames",
+											"RequestHeaderNames",
+										}, false),
+									},
+
+									"selector_match_operator": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											"Contains",
+											"EndsWith",
+											"Equals",
+											"EqualsAny",
+											"StartsWith",
+										}, false),
+									},
+
+									"selector": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validate.NoEmptyStrings,
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -1001,8 +1250,18 @@ func resourceArmApplicationGatewayCreateUpdate(d *schema.ResourceData, meta inte
 	sslPolicy := expandApplicationGatewaySslPolicy(d)
 	customErrorConfigurations := expandApplicationGatewayCustomErrorConfigurations(d.Get("custom_error_configuration").([]interface{}))
 	urlPathMaps := expandApplicationGatewayURLPathMaps(d, gatewayID)
+	rewriteRuleSets := expandApplicationGatewayRewriteRuleSets(d)
+	autoscaleConfiguration := expandApplicationGatewayAutoscaleConfiguration(d.Get("autoscale_configuration").([]interface{}))
 	zones := expandZones(d.Get("zones").([]interface{}))
 
+	if err := validateApplicationGatewaySku(sku, autoscaleConfiguration, zones); err != nil {
+		return err
+	}
+
+	if err := validateApplicationGatewaySslCertificates(d); err != nil {
+		return err
+	}
+
 	gateway := network.ApplicationGateway{
 		Location: utils.String(location),
 		Zones:    zones,
@@ -1025,9 +1284,25 @@ func resourceArmApplicationGatewayCreateUpdate(d *schema.ResourceData, meta inte
 			SslPolicy:                     sslPolicy,
 			CustomErrorConfigurations:     customErrorConfigurations,
 			URLPathMaps:                   urlPathMaps,
+			RewriteRuleSets:               rewriteRuleSets,
+			AutoscaleConfiguration:        autoscaleConfiguration,
 		},
 	}
 
+	if _, ok := d.GetOk("identity"); ok {
+		gateway.Identity = expandApplicationGatewayIdentity(d.Get("identity").([]interface{}))
+	}
+
+	if v, ok := d.GetOk("firewall_policy_id"); ok {
+		if sku.Tier != network.ApplicationGatewayTierWAFV2 {
+			return fmt.Errorf("`firewall_policy_id` can only be set when the `sku` tier is `WAF_v2`")
+		}
+
+		gateway.ApplicationGatewayPropertiesFormat.FirewallPolicy = &network.SubResource{
+			ID: utils.String(v.(string)),
+		}
+	}
+
 	for _, backendHttpSettings := range *backendHTTPSettingsCollection {
 		backendHttpSettingsProperties := *backendHttpSettings.ApplicationGatewayBackendHTTPSettingsPropertiesFormat
 		if backendHttpSettingsProperties.HostName != nil {
@@ -1109,12 +1384,20 @@ func resourceArmApplicationGatewayRead(d *schema.ResourceData, meta interface{})
 	}
 	d.Set("zones", applicationGateway.Zones)
 
+	if setErr := d.Set("identity", flattenApplicationGatewayIdentity(applicationGateway.Identity)); setErr != nil {
+		return fmt.Errorf("Error setting `identity`: %+v", setErr)
+	}
+
 	if props := applicationGateway.ApplicationGatewayPropertiesFormat; props != nil {
 		flattenedCerts := flattenApplicationGatewayAuthenticationCertificates(props.AuthenticationCertificates, d)
 		if setErr := d.Set("authentication_certificate", flattenedCerts); setErr != nil {
 			return fmt.Errorf("Error setting `authentication_certificate`: %+v", setErr)
 		}
 
+		if setErr := d.Set("autoscale_configuration", flattenApplicationGatewayAutoscaleConfiguration(props.AutoscaleConfiguration)); setErr != nil {
+			return fmt.Errorf("Error setting `autoscale_configuration`: %+v", setErr)
+		}
+
 		if setErr := d.Set("backend_address_pool", flattenApplicationGatewayBackendAddressPools(props.BackendAddressPools)); setErr != nil {
 			return fmt.Errorf("Error setting `backend_address_pool`: %+v", setErr)
 		}
@@ -1133,6 +1416,12 @@ func resourceArmApplicationGatewayRead(d *schema.ResourceData, meta interface{})
 
 		d.Set("enable_http2", props.EnableHTTP2)
 
+		firewallPolicyId := ""
+		if policy := props.FirewallPolicy; policy != nil && policy.ID != nil {
+			firewallPolicyId = *policy.ID
+		}
+		d.Set("firewall_policy_id", firewallPolicyId)
+
 		httpListeners, err := flattenApplicationGatewayHTTPListeners(props.HTTPListeners)
 		if err != nil {
 			return fmt.Errorf("Error flattening `http_listener`: %+v", err)
@@ -1173,6 +1462,10 @@ func resourceArmApplicationGatewayRead(d *schema.ResourceData, meta interface{})
 			return fmt.Errorf("Error setting `redirect configuration`: %+v", setErr)
 		}
 
+		if setErr := d.Set("rewrite_rule_set", flattenApplicationGatewayRewriteRuleSets(props.RewriteRuleSets)); setErr != nil {
+			return fmt.Errorf("Error setting `rewrite_rule_set`: %+v", setErr)
+		}
+
 		if setErr := d.Set("sku", flattenApplicationGatewaySku(props.Sku)); setErr != nil {
 			return fmt.Errorf("Error setting `sku`: %+v", setErr)
 		}
@@ -2070,6 +2363,13 @@ func expandApplicationGatewayRequestRoutingRules(d *schema.ResourceData, gateway
 			}
 		}
 
+		if rewriteRuleSetName := v["rewrite_rule_set_name"].(string); rewriteRuleSetName != "" {
+			rewriteRuleSetID := fmt.Sprintf("%s/rewriteRuleSets/%s", gatewayID, rewriteRuleSetName)
+			rule.ApplicationGatewayRequestRoutingRulePropertiesFormat.RewriteRuleSet = &network.SubResource{
+				ID: utils.String(rewriteRuleSetID),
+			}
+		}
+
 		results = append(results, rule)
 	}
 
@@ -2165,6 +2465,17 @@ func flattenApplicationGatewayRequestRoutingRules(input *[]network.ApplicationGa
 				}
 			}
 
+			if rewrite := props.RewriteRuleSet; rewrite != nil {
+				if rewrite.ID != nil {
+					rewriteId, err := parseAzureResourceID(*rewrite.ID)
+					if err != nil {
+						return nil, err
+					}
+					output["rewrite_rule_set_name"] = rewriteId.Path["rewriteRuleSets"]
+					output["rewrite_rule_set_id"] = *rewrite.ID
+				}
+			}
+
 			results = append(results, output)
 		}
 	}
@@ -2282,18 +2593,270 @@ func flattenApplicationGatewayRedirectConfigurations(input *[]network.Applicatio
 	return results, nil
 }
 
+func expandApplicationGatewayRewriteRuleSets(d *schema.ResourceData) *[]network.ApplicationGatewayRewriteRuleSet {
+	vs := d.Get("rewrite_rule_set").([]interface{})
+	results := make([]network.ApplicationGatewayRewriteRuleSet, 0)
+
+	for _, raw := range vs {
+		v := raw.(map[string]interface{})
+
+		rules := make([]network.ApplicationGatewayRewriteRule, 0)
+		for _, ruleRaw := range v["rewrite_rule"].([]interface{}) {
+			r := ruleRaw.(map[string]interface{})
+
+			conditions := make([]network.ApplicationGatewayRewriteRuleCondition, 0)
+			for _, conditionRaw := range r["condition"].([]interface{}) {
+				c := conditionRaw.(map[string]interface{})
+				conditions = append(conditions, network.ApplicationGatewayRewriteRuleCondition{
+					Variable:   utils.String(c["variable"].(string)),
+					Pattern:    utils.String(c["pattern"].(string)),
+					IgnoreCase: utils.Bool(c["ignore_case"].(bool)),
+					Negate:     utils.Bool(c["negate"].(bool)),
+				})
+			}
+
+			rules = append(rules, network.ApplicationGatewayRewriteRule{
+				Name:         utils.String(r["name"].(string)),
+				RuleSequence: utils.Int32(int32(r["rule_sequence"].(int))),
+				Conditions:   &conditions,
+				ActionSet: &network.ApplicationGatewayRewriteRuleActionSet{
+					RequestHeaderConfigurations:  expandApplicationGatewayRewriteRuleHeaderConfigurations(r["request_header_configuration"].([]interface{})),
+					ResponseHeaderConfigurations: expandApplicationGatewayRewriteRuleHeaderConfigurations(r["response_header_configuration"].([]interface{})),
+				},
+			})
+		}
+
+		results = append(results, network.ApplicationGatewayRewriteRuleSet{
+			Name: utils.String(v["name"].(string)),
+			ApplicationGatewayRewriteRuleSetPropertiesFormat: &network.ApplicationGatewayRewriteRuleSetPropertiesFormat{
+				RewriteRules: &rules,
+			},
+		})
+	}
+
+	return &results
+}
+
+func expandApplicationGatewayRewriteRuleHeaderConfigurations(input []interface{}) *[]network.ApplicationGatewayHeaderConfiguration {
+	results := make([]network.ApplicationGatewayHeaderConfiguration, 0)
+
+	for _, raw := range input {
+		v := raw.(map[string]interface{})
+		results = append(results, network.ApplicationGatewayHeaderConfiguration{
+			HeaderName:  utils.String(v["header_name"].(string)),
+			HeaderValue: utils.String(v["header_value"].(string)),
+		})
+	}
+
+	return &results
+}
+
+func flattenApplicationGatewayRewriteRuleSets(input *[]network.ApplicationGatewayRewriteRuleSet) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, set := range *input {
+		output := map[string]interface{}{}
+
+		if set.ID != nil {
+			output["id"] = *set.ID
+		}
+
+		if set.Name != nil {
+			output["name"] = *set.Name
+		}
+
+		rules := make([]interface{}, 0)
+		if props := set.ApplicationGatewayRewriteRuleSetPropertiesFormat; props != nil && props.RewriteRules != nil {
+			for _, rule := range *props.RewriteRules {
+				ruleOutput := map[string]interface{}{}
+
+				if rule.Name != nil {
+					ruleOutput["name"] = *rule.Name
+				}
+
+				if rule.RuleSequence != nil {
+					ruleOutput["rule_sequence"] = int(*rule.RuleSequence)
+				}
+
+				conditions := make([]interface{}, 0)
+				if rule.Conditions != nil {
+					for _, condition := range *rule.Conditions {
+						conditionOutput := map[string]interface{}{}
+
+						if condition.Variable != nil {
+							conditionOutput["variable"] = *condition.Variable
+						}
+
+						if condition.Pattern != nil {
+							conditionOutput["pattern"] = *condition.Pattern
+						}
+
+						if condition.IgnoreCase != nil {
+							conditionOutput["ignore_case"] = *condition.IgnoreCase
+						}
+
+						if condition.Negate != nil {
+							conditionOutput["negate"] = *condition.Negate
+						}
+
+						conditions = append(conditions, conditionOutput)
+					}
+				}
+				ruleOutput["condition"] = conditions
+
+				if actionSet := rule.ActionSet; actionSet != nil {
+					ruleOutput["request_header_configuration"] = flattenApplicationGatewayRewriteRuleHeaderConfigurations(actionSet.RequestHeaderConfigurations)
+					ruleOutput["response_header_configuration"] = flattenApplicationGatewayRewriteRuleHeaderConfigurations(actionSet.ResponseHeaderConfigurations)
+				}
+
+				rules = append(rules, ruleOutput)
+			}
+		}
+		output["rewrite_rule"] = rules
+
+		results = append(results, output)
+	}
+
+	return results
+}
+
+func flattenApplicationGatewayRewriteRuleHeaderConfigurations(input *[]network.ApplicationGatewayHeaderConfiguration) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, header := range *input {
+		output := map[string]interface{}{}
+
+		if header.HeaderName != nil {
+			output["header_name"] = *header.HeaderName
+		}
+
+		if header.HeaderValue != nil {
+			output["header_value"] = *header.HeaderValue
+		}
+
+		results = append(results, output)
+	}
+
+	return results
+}
+
 func expandApplicationGatewaySku(d *schema.ResourceData) *network.ApplicationGatewaySku {
 	vs := d.Get("sku").([]interface{})
 	v := vs[0].(map[string]interface{})
 
 	name := v["name"].(string)
 	tier := v["tier"].(string)
-	capacity := int32(v["capacity"].(int))
 
-	return &network.ApplicationGatewaySku{
-		Name:     network.ApplicationGatewaySkuName(name),
-		Tier:     network.ApplicationGatewayTier(tier),
-		Capacity: utils.Int32(capacity),
+	sku := network.ApplicationGatewaySku{
+		Name: network.ApplicationGatewaySkuName(name),
+		Tier: network.ApplicationGatewayTier(tier),
+	}
+
+	// the capacity is omitted when the gateway is autoscaled
+	if capacity := v["capacity"].(int); capacity > 0 {
+		sku.Capacity = utils.Int32(int32(capacity))
+	}
+
+	return &sku
+}
+
+func validateApplicationGatewaySku(sku *network.ApplicationGatewaySku, autoscale *network.ApplicationGatewayAutoscaleConfiguration, zones *[]string) error {
+	isV2 := strings.EqualFold(string(sku.Tier), string(network.ApplicationGatewayTierStandardV2)) || strings.EqualFold(string(sku.Tier), string(network.ApplicationGatewayTierWAFV2))
+
+	if autoscale == nil {
+		if sku.Capacity == nil {
+			return fmt.Errorf("`sku.0.capacity` must be set when `autoscale_configuration` isn't specified")
+		}
+	} else {
+		if !isV2 {
+			return fmt.Errorf("`autoscale_configuration` can only be specified for the `Standard_v2` and `WAF_v2` tiers")
+		}
+
+		if sku.Capacity != nil {
+			return fmt.Errorf("`sku.0.capacity` cannot be set when `autoscale_configuration` is specified")
+		}
+
+		if autoscale.MaxCapacity != nil && *autoscale.MaxCapacity < *autoscale.MinCapacity {
+			return fmt.Errorf("`autoscale_configuration.0.max_capacity` must be greater than or equal to `min_capacity`")
+		}
+	}
+
+	if zones != nil && len(*zones) > 0 && !isV2 {
+		return fmt.Errorf("`zones` can only be specified for the `Standard_v2` and `WAF_v2` tiers")
+	}
+
+	return nil
+}
+
+func expandApplicationGatewayAutoscaleConfiguration(input []interface{}) *network.ApplicationGatewayAutoscaleConfiguration {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+
+	config := network.ApplicationGatewayAutoscaleConfiguration{
+		MinCapacity: utils.Int32(int32(v["min_capacity"].(int))),
+	}
+
+	if maxCapacity := v["max_capacity"].(int); maxCapacity > 0 {
+		config.MaxCapacity = utils.Int32(int32(maxCapacity))
+	}
+
+	return &config
+}
+
+func flattenApplicationGatewayAutoscaleConfiguration(input *network.ApplicationGatewayAutoscaleConfiguration) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	result := make(map[string]interface{})
+	if input.MinCapacity != nil {
+		result["min_capacity"] = int(*input.MinCapacity)
+	}
+	if input.MaxCapacity != nil {
+		result["max_capacity"] = int(*input.MaxCapacity)
+	}
+
+	return []interface{}{result}
+}
+
+func expandApplicationGatewayIdentity(input []interface{}) *network.ManagedServiceIdentity {
+	v := input[0].(map[string]interface{})
+
+	identityIds := make(map[string]*network.ManagedServiceIdentityUserAssignedIdentitiesValue)
+	for _, id := range v["identity_ids"].([]interface{}) {
+		identityIds[id.(string)] = &network.ManagedServiceIdentityUserAssignedIdentitiesValue{}
+	}
+
+	return &network.ManagedServiceIdentity{
+		Type:                   network.ResourceIdentityType(v["type"].(string)),
+		UserAssignedIdentities: identityIds,
+	}
+}
+
+func flattenApplicationGatewayIdentity(input *network.ManagedServiceIdentity) []interface{} {
+	if input == nil || input.Type == network.ResourceIdentityTypeNone || input.Type == "" {
+		return []interface{}{}
+	}
+
+	identityIds := make([]string, 0)
+	for key := range input.UserAssignedIdentities {
+		identityIds = append(identityIds, key)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type":         string(input.Type),
+			"identity_ids": identityIds,
+		},
 	}
 }
 
@@ -2319,16 +2882,19 @@ func expandApplicationGatewaySslCertificates(d *schema.ResourceData) *[]network.
 		name := v["name"].(string)
 		data := v["data"].(string)
 		password := v["password"].(string)
-
-		// data must be base64 encoded
-		data = base64Encode(data)
+		keyVaultSecretId := v["key_vault_secret_id"].(string)
 
 		output := network.ApplicationGatewaySslCertificate{
 			Name: utils.String(name),
-			ApplicationGatewaySslCertificatePropertiesFormat: &network.ApplicationGatewaySslCertificatePropertiesFormat{
-				Data:     utils.String(data),
-				Password: utils.String(password),
-			},
+			ApplicationGatewaySslCertificatePropertiesFormat: &network.ApplicationGatewaySslCertificatePropertiesFormat{},
+		}
+
+		if keyVaultSecretId != "" {
+			output.ApplicationGatewaySslCertificatePropertiesFormat.KeyVaultSecretID = utils.String(keyVaultSecretId)
+		} else {
+			// data must be base64 encoded
+			output.ApplicationGatewaySslCertificatePropertiesFormat.Data = utils.String(base64Encode(data))
+			output.ApplicationGatewaySslCertificatePropertiesFormat.Password = utils.String(password)
 		}
 
 		results = append(results, output)
@@ -2361,6 +2927,10 @@ func flattenApplicationGatewaySslCertificates(input *[]network.ApplicationGatewa
 			if data := props.PublicCertData; data != nil {
 				output["public_cert_data"] = *data
 			}
+
+			if keyVaultSecretId := props.KeyVaultSecretID; keyVaultSecretId != nil {
+				output["key_vault_secret_id"] = *keyVaultSecretId
+			}
 		}
 
 		// since the certificate data isn't returned we have to load it from the same index
@@ -2371,7 +2941,7 @@ func flattenApplicationGatewaySslCertificates(input *[]network.ApplicationGatewa
 				existingName := existingCerts["name"].(string)
 
 				if name == existingName {
-					if data := existingCerts["data"]; data != nil {
+					if data := existingCerts["data"]; data != nil && data.(string) != "" {
 						v := base64Encode(data.(string))
 						output["data"] = v
 					}
@@ -2389,6 +2959,37 @@ func flattenApplicationGatewaySslCertificates(input *[]network.ApplicationGatewa
 	return results
 }
 
+func validateApplicationGatewaySslCertificates(d *schema.ResourceData) error {
+	vs := d.Get("ssl_certificate").([]interface{})
+
+	for _, raw := range vs {
+		v := raw.(map[string]interface{})
+
+		name := v["name"].(string)
+		data := v["data"].(string)
+		password := v["password"].(string)
+		keyVaultSecretId := v["key_vault_secret_id"].(string)
+
+		if keyVaultSecretId != "" {
+			if data != "" || password != "" {
+				return fmt.Errorf("`data` and `password` cannot be set for the `ssl_certificate` %q when `key_vault_secret_id` is specified", name)
+			}
+
+			if _, ok := d.GetOk("identity"); !ok {
+				return fmt.Errorf("An `identity` block must be specified to use the `key_vault_secret_id` of the `ssl_certificate` %q", name)
+			}
+
+			continue
+		}
+
+		if data == "" || password == "" {
+			return fmt.Errorf("Either `key_vault_secret_id` or both `data` and `password` must be set for the `ssl_certificate` %q", name)
+		}
+	}
+
+	return nil
+}
+
 func expandApplicationGatewayURLPathMaps(d *schema.ResourceData, gatewayID string) *[]network.ApplicationGatewayURLPathMap {
 	vs := d.Get("url_path_map").([]interface{})
 	results := make([]network.ApplicationGatewayURLPathMap, 0)
@@ -2437,6 +3038,13 @@ func expandApplicationGatewayURLPathMaps(d *schema.ResourceData, gatewayID strin
 				}
 			}
 
+			if rewriteRuleSetName := ruleConfigMap["rewrite_rule_set_name"].(string); rewriteRuleSetName != "" {
+				rewriteRuleSetID := fmt.Sprintf("%s/rewriteRuleSets/%s", gatewayID, rewriteRuleSetName)
+				rule.ApplicationGatewayPathRulePropertiesFormat.RewriteRuleSet = &network.SubResource{
+					ID: utils.String(rewriteRuleSetID),
+				}
+			}
+
 			pathRules = append(pathRules, rule)
 		}
 
@@ -2586,6 +3194,15 @@ func flattenApplicationGatewayURLPathMaps(input *[]network.ApplicationGatewayURL
 							ruleOutput["redirect_configuration_id"] = *redirect.ID
 						}
 
+						if rewrite := ruleProps.RewriteRuleSet; rewrite != nil && rewrite.ID != nil {
+							rewriteId, err := parseAzureResourceID(*rewrite.ID)
+							if err != nil {
+								return nil, err
+							}
+							ruleOutput["rewrite_rule_set_name"] = rewriteId.Path["rewriteRuleSets"]
+							ruleOutput["rewrite_rule_set_id"] = *rewrite.ID
+						}
+
 						pathOutputs := make([]interface{}, 0)
 						if paths := ruleProps.Paths; paths != nil {
 							for _, rulePath := range *paths {
@@ -2627,7 +3244,58 @@ func expandApplicationGatewayWafConfig(d *schema.ResourceData) *network.Applicat
 		FileUploadLimitInMb:    utils.Int32(int32(fileUploadLimitInMb)),
 		RequestBodyCheck:       utils.Bool(requestBodyCheck),
 		MaxRequestBodySizeInKb: utils.Int32(int32(maxRequestBodySizeInKb)),
+		DisabledRuleGroups:     expandApplicationGatewayWafDisabledRuleGroups(v["disabled_rule_group"].([]interface{})),
+		Exclusions:             expandApplicationGatewayWafExclusions(v["exclusion"].([]interface{})),
+	}
+}
+
+func expandApplicationGatewayWafDisabledRuleGroups(input []interface{}) *[]network.ApplicationGatewayFirewallDisabledRuleGroup {
+	results := make([]network.ApplicationGatewayFirewallDisabledRuleGroup, 0)
+
+	for _, raw := range input {
+		v := raw.(map[string]interface{})
+
+		output := network.ApplicationGatewayFirewallDisabledRuleGroup{
+			RuleGroupName: utils.String(v["rule_group_name"].(string)),
+		}
+
+		// when no rules are specified the whole rule group is disabled
+		if rules := v["rules"].([]interface{}); len(rules) > 0 {
+			ruleIds := make([]int32, 0)
+			for _, rule := range rules {
+				ruleIds = append(ruleIds, int32(rule.(int)))
+			}
+			output.Rules = &ruleIds
+		}
+
+		results = append(results, output)
+	}
+
+	return &results
+}
+
+func expandApplicationGatewayWafExclusions(input []interface{}) *[]network.ApplicationGatewayFirewallExclusion {
+	results := make([]network.ApplicationGatewayFirewallExclusion, 0)
+
+	for _, raw := range input {
+		v := raw.(map[string]interface{})
+
+		output := network.ApplicationGatewayFirewallExclusion{
+			MatchVariable: utils.String(v["match_variable"].(string)),
+		}
+
+		if operator := v["selector_match_operator"].(string); operator != "" {
+			output.SelectorMatchOperator = utils.String(operator)
+		}
+
+		if selector := v["selector"].(string); selector != "" {
+			output.Selector = utils.String(selector)
+		}
+
+		results = append(results, output)
 	}
+
+	return &results
 }
 
 func flattenApplicationGatewayWafConfig(input *network.ApplicationGatewayWebApplicationFirewallConfiguration) []interface{} {
@@ -2664,11 +3332,68 @@ func flattenApplicationGatewayWafConfig(input *network.ApplicationGatewayWebAppl
 		output["max_request_body_size_kb"] = int(*input.MaxRequestBodySizeInKb)
 	}
 
+	output["disabled_rule_group"] = flattenApplicationGatewayWafDisabledRuleGroups(input.DisabledRuleGroups)
+	output["exclusion"] = flattenApplicationGatewayWafExclusions(input.Exclusions)
+
 	results = append(results, output)
 
 	return results
 }
 
+func flattenApplicationGatewayWafDisabledRuleGroups(input *[]network.ApplicationGatewayFirewallDisabledRuleGroup) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, group := range *input {
+		output := make(map[string]interface{})
+
+		if group.RuleGroupName != nil {
+			output["rule_group_name"] = *group.RuleGroupName
+		}
+
+		rules := make([]interface{}, 0)
+		if group.Rules != nil {
+			for _, rule := range *group.Rules {
+				rules = append(rules, int(rule))
+			}
+		}
+		output["rules"] = rules
+
+		results = append(results, output)
+	}
+
+	return results
+}
+
+func flattenApplicationGatewayWafExclusions(input *[]network.ApplicationGatewayFirewallExclusion) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, exclusion := range *input {
+		output := make(map[string]interface{})
+
+		if exclusion.MatchVariable != nil {
+			output["match_variable"] = *exclusion.MatchVariable
+		}
+
+		if exclusion.SelectorMatchOperator != nil {
+			output["selector_match_operator"] = *exclusion.SelectorMatchOperator
+		}
+
+		if exclusion.Selector != nil {
+			output["selector"] = *exclusion.Selector
+		}
+
+		results = append(results, output)
+	}
+
+	return results
+}
+
 func expandApplicationGatewayCustomErrorConfigurations(vs []interface{}) *[]network.ApplicationGatewayCustomError {
 	results := make([]network.ApplicationGatewayCustomError, 0)
 
@@ -0,0 +1,610 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/dns/mgmt/2018-03-01-preview/dns"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/zonefile"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmDnsZoneRecords() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmDnsZoneRecordsCreateUpdate,
+		Read:   resourceArmDnsZoneRecordsRead,
+		Update: resourceArmDnsZoneRecordsCreateUpdate,
+		Delete: resourceArmDnsZoneRecordsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameDiffSuppressSchema(),
+
+			"zone_file": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"default_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3600,
+				ValidateFunc: validation.IntBetween(0, 2147483647),
+			},
+
+			// the Record Sets parsed from the zone file, exposed so that changes are shown per Record Set
+			"record_set": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"ttl": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"records": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+
+		CustomizeDiff: resourceArmDnsZoneRecordsCustomizeDiff,
+	}
+}
+
+func resourceArmDnsZoneRecordsCustomizeDiff(d *schema.ResourceDiff, v interface{}) error {
+	if !d.NewValueKnown("zone_file") || !d.NewValueKnown("zone_name") || !d.NewValueKnown("default_ttl") {
+		return d.SetNewComputed("record_set")
+	}
+
+	zoneName := d.Get("zone_name").(string)
+	recordSets, err := parseArmDnsZoneRecordsZoneFile(d.Get("zone_file").(string), zoneName, d.Get("default_ttl").(int))
+	if err != nil {
+		return fmt.Errorf("Error parsing `zone_file` for DNS Zone %q: %+v", zoneName, err)
+	}
+
+	return d.SetNew("record_set", flattenArmDnsZoneRecordSets(recordSets))
+}
+
+func resourceArmDnsZoneRecordsCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).dnsClient
+	zonesClient := meta.(*ArmClient).zonesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	zoneName := d.Get("zone_name").(string)
+	resGroup := d.Get("resource_group_name").(string)
+
+	zone, err := zonesClient.Get(ctx, resGroup, zoneName)
+	if err != nil {
+		return fmt.Errorf("Error retrieving DNS Zone %q (Resource Group %q): %+v", zoneName, resGroup, err)
+	}
+	if zone.ID == nil {
+		return fmt.Errorf("Cannot read DNS Zone %q (Resource Group %q) ID", zoneName, resGroup)
+	}
+
+	desired, err := parseArmDnsZoneRecordsZoneFile(d.Get("zone_file").(string), zoneName, d.Get("default_ttl").(int))
+	if err != nil {
+		return fmt.Errorf("Error parsing `zone_file` for DNS Zone %q: %+v", zoneName, err)
+	}
+
+	existing, metadata, err := retrieveArmDnsZoneRecordSets(meta, resGroup, zoneName)
+	if err != nil {
+		return err
+	}
+
+	desiredByKey := make(map[string]zonefile.RecordSet)
+	for _, set := range desired {
+		desiredByKey[set.Key()] = set
+	}
+
+	existingByKey := make(map[string]zonefile.RecordSet)
+	for _, set := range existing {
+		existingByKey[set.Key()] = set
+	}
+
+	// Record Sets are removed first, since a CNAME can't be added alongside other records with the same name
+	for _, set := range existing {
+		if _, ok := desiredByKey[set.Key()]; ok {
+			continue
+		}
+
+		log.Printf("[DEBUG] Deleting Record Set %q from DNS Zone %q (Resource Group %q)", set.Key(), zoneName, resGroup)
+		if err := deleteArmDnsZoneRecordSet(meta, resGroup, zoneName, set); err != nil {
+			return err
+		}
+	}
+
+	for _, set := range desired {
+		if current, ok := existingByKey[set.Key()]; ok && reflect.DeepEqual(current, set) {
+			continue
+		}
+
+		log.Printf("[DEBUG] Creating/Updating Record Set %q in DNS Zone %q (Resource Group %q)", set.Key(), zoneName, resGroup)
+		properties, err := expandArmDnsZoneRecordSet(set)
+		if err != nil {
+			return fmt.Errorf("Error expanding Record Set %q for DNS Zone %q (Resource Group %q): %+v", set.Key(), zoneName, resGroup, err)
+		}
+		properties.Metadata = metadata[set.Key()]
+
+		parameters := dns.RecordSet{
+			Name:                utils.String(set.Name),
+			RecordSetProperties: properties,
+		}
+
+		eTag := ""
+		ifNoneMatch := "" // set to empty to allow updates to records after creation
+		if _, err := client.CreateOrUpdate(ctx, resGroup, zoneName, set.Name, dns.RecordType(set.Type), parameters, eTag, ifNoneMatch); err != nil {
+			return fmt.Errorf("Error creating/updating Record Set %q in DNS Zone %q (Resource Group %q): %+v", set.Key(), zoneName, resGroup, err)
+		}
+	}
+
+	d.SetId(*zone.ID)
+
+	return resourceArmDnsZoneRecordsRead(d, meta)
+}
+
+func resourceArmDnsZoneRecordsRead(d *schema.ResourceData, meta interface{}) error {
+	zonesClient := meta.(*ArmClient).zonesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resGroup := id.ResourceGroup
+	zoneName := id.Path["dnszones"]
+
+	resp, err := zonesClient.Get(ctx, resGroup, zoneName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] DNS Zone %q does not exist - removing Zone Records from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading DNS Zone %q (Resource Group %q): %+v", zoneName, resGroup, err)
+	}
+
+	recordSets, _, err := retrieveArmDnsZoneRecordSets(meta, resGroup, zoneName)
+	if err != nil {
+		return err
+	}
+
+	d.Set("zone_name", zoneName)
+	d.Set("resource_group_name", resGroup)
+
+	if err := d.Set("record_set", flattenArmDnsZoneRecordSets(recordSets)); err != nil {
+		return fmt.Errorf("Error setting `record_set`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceArmDnsZoneRecordsDelete(d *schema.ResourceData, meta interface{}) error {
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resGroup := id.ResourceGroup
+	zoneName := id.Path["dnszones"]
+
+	// only the Record Sets defined in the `zone_file` are removed - any others in the zone (e.g. those created
+	// outside of Terraform or by the `azurerm_dns_*_record` resources) aren't managed by this resource
+	recordSets, err := parseArmDnsZoneRecordsZoneFile(d.Get("zone_file").(string), zoneName, d.Get("default_ttl").(int))
+	if err != nil {
+		return fmt.Errorf("Error parsing `zone_file` for DNS Zone %q: %+v", zoneName, err)
+	}
+
+	for _, set := range recordSets {
+		log.Printf("[DEBUG] Deleting Record Set %q from DNS Zone %q (Resource Group %q)", set.Key(), zoneName, resGroup)
+		if err := deleteArmDnsZoneRecordSet(meta, resGroup, zoneName, set); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseArmDnsZoneRecordsZoneFile(input string, zoneName string, defaultTTL int) ([]zonefile.RecordSet, error) {
+	recordSets, err := zonefile.Parse(input, zoneName, int64(defaultTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	// the SOA and NS records at the apex of the zone are managed by Azure and are left untouched
+	results := make([]zonefile.RecordSet, 0)
+	for _, set := range recordSets {
+		if !set.IsZoneAuthority() {
+			results = append(results, set)
+		}
+	}
+
+	return results, nil
+}
+
+// retrieveArmDnsZoneRecordSets returns all of the Record Sets within the zone other than the apex SOA and NS
+// Record Sets, alongside the Metadata of each Record Set so that it can be retained when the records are updated
+func retrieveArmDnsZoneRecordSets(meta interface{}, resGroup string, zoneName string) ([]zonefile.RecordSet, map[string]map[string]*string, error) {
+	client := meta.(*ArmClient).dnsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	results := make([]zonefile.RecordSet, 0)
+	metadata := make(map[string]map[string]*string)
+
+	iterator, err := client.ListByDNSZoneComplete(ctx, resGroup, zoneName, nil, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error listing Record Sets for DNS Zone %q (Resource Group %q): %+v", zoneName, resGroup, err)
+	}
+
+	for iterator.NotDone() {
+		set, err := flattenArmDnsZoneRecordSet(iterator.Value())
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error flattening Record Set for DNS Zone %q (Resource Group %q): %+v", zoneName, resGroup, err)
+		}
+
+		if set != nil && !set.IsZoneAuthority() {
+			results = append(results, *set)
+			if props := iterator.Value().RecordSetProperties; props != nil {
+				metadata[set.Key()] = props.Metadata
+			}
+		}
+
+		if err := iterator.Next(); err != nil {
+			return nil, nil, fmt.Errorf("Error listing Record Sets for DNS Zone %q (Resource Group %q): %+v", zoneName, resGroup, err)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Key() < results[j].Key()
+	})
+
+	return results, metadata, nil
+}
+
+func deleteArmDnsZoneRecordSet(meta interface{}, resGroup string, zoneName string, set zonefile.RecordSet) error {
+	client := meta.(*ArmClient).dnsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resp, err := client.Delete(ctx, resGroup, zoneName, set.Name, dns.RecordType(set.Type), "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting Record Set %q from DNS Zone %q (Resource Group %q): %+v", set.Key(), zoneName, resGroup, err)
+	}
+
+	return nil
+}
+
+func flattenArmDnsZoneRecordSets(input []zonefile.RecordSet) []interface{} {
+	results := make([]interface{}, 0)
+
+	for _, set := range input {
+		results = append(results, map[string]interface{}{
+			"name":    set.Name,
+			"type":    set.Type,
+			"ttl":     int(set.TTL),
+			"records": utils.FlattenStringArray(&set.Records),
+		})
+	}
+
+	return results
+}
+
+// flattenArmDnsZoneRecordSet converts a Record Set from the API into the same canonical form as the zone file parser,
+// returning nil for Record Sets which can't be represented in a zone file
+func flattenArmDnsZoneRecordSet(input dns.RecordSet) (*zonefile.RecordSet, error) {
+	if input.Name == nil || input.Type == nil || input.RecordSetProperties == nil {
+		return nil, nil
+	}
+
+	props := *input.RecordSetProperties
+	recordType := (*input.Type)[strings.LastIndex(*input.Type, "/")+1:]
+
+	set := zonefile.RecordSet{
+		Name:    strings.ToLower(*input.Name),
+		Type:    strings.ToUpper(recordType),
+		Records: make([]string, 0),
+	}
+
+	if props.TTL != nil {
+		set.TTL = *props.TTL
+	}
+
+	switch set.Type {
+	case string(dns.A):
+		if props.ARecords != nil {
+			for _, r := range *props.ARecords {
+				if r.Ipv4Address != nil {
+					set.Records = append(set.Records, normalizeArmDnsZoneRecordIPAddress(*r.Ipv4Address))
+				}
+			}
+		}
+
+	case string(dns.AAAA):
+		if props.AaaaRecords != nil {
+			for _, r := range *props.AaaaRecords {
+				if r.Ipv6Address != nil {
+					set.Records = append(set.Records, normalizeArmDnsZoneRecordIPAddress(*r.Ipv6Address))
+				}
+			}
+		}
+
+	case string(dns.CAA):
+		if props.CaaRecords != nil {
+			for _, r := range *props.CaaRecords {
+				if r.Flags != nil && r.Tag != nil && r.Value != nil {
+					set.Records = append(set.Records, fmt.Sprintf("%d %s %s", *r.Flags, strings.ToLower(*r.Tag), zonefile.Quote(*r.Value)))
+				}
+			}
+		}
+
+	case string(dns.CNAME):
+		if props.CnameRecord != nil && props.CnameRecord.Cname != nil {
+			set.Records = append(set.Records, strings.TrimSuffix(*props.CnameRecord.Cname, "."))
+		}
+
+	case string(dns.MX):
+		if props.MxRecords != nil {
+			for _, r := range *props.MxRecords {
+				if r.Preference != nil && r.Exchange != nil {
+					set.Records = append(set.Records, fmt.Sprintf("%d %s", *r.Preference, strings.TrimSuffix(*r.Exchange, ".")))
+				}
+			}
+		}
+
+	case string(dns.NS):
+		if props.NsRecords != nil {
+			for _, r := range *props.NsRecords {
+				if r.Nsdname != nil {
+					set.Records = append(set.Records, strings.TrimSuffix(*r.Nsdname, "."))
+				}
+			}
+		}
+
+	case string(dns.PTR):
+		if props.PtrRecords != nil {
+			for _, r := range *props.PtrRecords {
+				if r.Ptrdname != nil {
+					set.Records = append(set.Records, strings.TrimSuffix(*r.Ptrdname, "."))
+				}
+			}
+		}
+
+	case string(dns.SRV):
+		if props.SrvRecords != nil {
+			for _, r := range *props.SrvRecords {
+				if r.Priority != nil && r.Weight != nil && r.Port != nil && r.Target != nil {
+					set.Records = append(set.Records, fmt.Sprintf("%d %d %d %s", *r.Priority, *r.Weight, *r.Port, strings.TrimSuffix(*r.Target, ".")))
+				}
+			}
+		}
+
+	case string(dns.TXT):
+		if props.TxtRecords != nil {
+			for _, r := range *props.TxtRecords {
+				if r.Value == nil {
+					continue
+				}
+
+				values := make([]string, 0)
+				for _, v := range *r.Value {
+					values = append(values, zonefile.Quote(v))
+				}
+				set.Records = append(set.Records, strings.Join(values, " "))
+			}
+		}
+
+	case string(dns.SOA):
+		// the SOA Record Set only exists at the apex, which isn't managed
+		return &set, nil
+
+	default:
+		log.Printf("[DEBUG] Ignoring Record Set %q with unsupported type %q", *input.Name, *input.Type)
+		return nil, nil
+	}
+
+	sort.Strings(set.Records)
+
+	return &set, nil
+}
+
+func expandArmDnsZoneRecordSet(input zonefile.RecordSet) (*dns.RecordSetProperties, error) {
+	props := dns.RecordSetProperties{
+		TTL: utils.Int64(input.TTL),
+	}
+
+	fields := make([][]string, 0)
+	for _, record := range input.Records {
+		v, err := zonefile.SplitRecordData(record)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, v)
+	}
+
+	expectFields := func(count int) error {
+		for _, f := range fields {
+			if len(f) != count {
+				return fmt.Errorf("expected %d field(s) for a %s record but got %d", count, input.Type, len(f))
+			}
+		}
+		return nil
+	}
+
+	parseInt32 := func(value string) (*int32, error) {
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		return utils.Int32(int32(v)), nil
+	}
+
+	switch input.Type {
+	case string(dns.A):
+		if err := expectFields(1); err != nil {
+			return nil, err
+		}
+		records := make([]dns.ARecord, 0)
+		for _, f := range fields {
+			records = append(records, dns.ARecord{Ipv4Address: utils.String(f[0])})
+		}
+		props.ARecords = &records
+
+	case string(dns.AAAA):
+		if err := expectFields(1); err != nil {
+			return nil, err
+		}
+		records := make([]dns.AaaaRecord, 0)
+		for _, f := range fields {
+			records = append(records, dns.AaaaRecord{Ipv6Address: utils.String(f[0])})
+		}
+		props.AaaaRecords = &records
+
+	case string(dns.CAA):
+		if err := expectFields(3); err != nil {
+			return nil, err
+		}
+		records := make([]dns.CaaRecord, 0)
+		for _, f := range fields {
+			flags, err := parseInt32(f[0])
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, dns.CaaRecord{
+				Flags: flags,
+				Tag:   utils.String(f[1]),
+				Value: utils.String(f[2]),
+			})
+		}
+		props.CaaRecords = &records
+
+	case string(dns.CNAME):
+		if err := expectFields(1); err != nil {
+			return nil, err
+		}
+		if len(fields) != 1 {
+			return nil, fmt.Errorf("a CNAME Record Set must contain a single record")
+		}
+		props.CnameRecord = &dns.CnameRecord{Cname: utils.String(fields[0][0])}
+
+	case string(dns.MX):
+		if err := expectFields(2); err != nil {
+			return nil, err
+		}
+		records := make([]dns.MxRecord, 0)
+		for _, f := range fields {
+			preference, err := parseInt32(f[0])
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, dns.MxRecord{
+				Preference: preference,
+				Exchange:   utils.String(f[1]),
+			})
+		}
+		props.MxRecords = &records
+
+	case string(dns.NS):
+		if err := expectFields(1); err != nil {
+			return nil, err
+		}
+		records := make([]dns.NsRecord, 0)
+		for _, f := range fields {
+			records = append(records, dns.NsRecord{Nsdname: utils.String(f[0])})
+		}
+		props.NsRecords = &records
+
+	case string(dns.PTR):
+		if err := expectFields(1); err != nil {
+			return nil, err
+		}
+		records := make([]dns.PtrRecord, 0)
+		for _, f := range fields {
+			records = append(records, dns.PtrRecord{Ptrdname: utils.String(f[0])})
+		}
+		props.PtrRecords = &records
+
+	case string(dns.SRV):
+		if err := expectFields(4); err != nil {
+			return nil, err
+		}
+		records := make([]dns.SrvRecord, 0)
+		for _, f := range fields {
+			values := make([]*int32, 0, 3)
+			for _, v := range f[0:3] {
+				i, err := parseInt32(v)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, i)
+			}
+			records = append(records, dns.SrvRecord{
+				Priority: values[0],
+				Weight:   values[1],
+				Port:     values[2],
+				Target:   utils.String(f[3]),
+			})
+		}
+		props.SrvRecords = &records
+
+	case string(dns.TXT):
+		records := make([]dns.TxtRecord, 0)
+		for _, f := range fields {
+			value := f
+			records = append(records, dns.TxtRecord{Value: &value})
+		}
+		props.TxtRecords = &records
+
+	default:
+		return nil, fmt.Errorf("the record type %q is not supported", input.Type)
+	}
+
+	return &props, nil
+}
+
+func normalizeArmDnsZoneRecordIPAddress(input string) string {
+	if ip := net.ParseIP(input); ip != nil {
+		if four := ip.To4(); four != nil {
+			return four.String()
+		}
+		return ip.String()
+	}
+
+	return input
+}
@@ -0,0 +1,257 @@
+package azurerm
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/dns/mgmt/2018-03-01-preview/dns"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAzureRMDnsZoneRecords_expandFlattenRoundTrip(t *testing.T) {
+	input := `
+$TTL 300
+@        MX    10 mail
+         MX    20 mail.backup.example.net.
+@        TXT   "v=spf1 -all"
+long     TXT   "first chunk" "with \"quotes\""
+@        CAA   0 issue "letsencrypt.org"
+www      A     10.0.0.1
+         A     10.0.0.2
+ipv6     AAAA  2001:db8::1
+ftp      CNAME www
+_sip._tcp SRV  10 60 5060 sip
+delegated NS   ns1.delegated.example.com.
+4.3      PTR   www
+`
+
+	recordSets, err := parseArmDnsZoneRecordsZoneFile(input, "example.com", 3600)
+	if err != nil {
+		t.Fatalf("Expected no error parsing the zone file but got: %+v", err)
+	}
+
+	for _, expected := range recordSets {
+		t.Run(expected.Key(), func(t *testing.T) {
+			props, err := expandArmDnsZoneRecordSet(expected)
+			if err != nil {
+				t.Fatalf("Expected no error expanding but got: %+v", err)
+			}
+
+			apiType := fmt.Sprintf("Microsoft.Network/dnszones/%s", expected.Type)
+			actual, err := flattenArmDnsZoneRecordSet(dns.RecordSet{
+				Name:                utils.String(expected.Name),
+				Type:                utils.String(apiType),
+				RecordSetProperties: props,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error flattening but got: %+v", err)
+			}
+
+			if actual == nil || !reflect.DeepEqual(expected, *actual) {
+				t.Fatalf("Expected %+v but got %+v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestAzureRMDnsZoneRecords_zoneAuthorityIgnored(t *testing.T) {
+	input := `
+@  SOA ns1 hostmaster 1 3600 300 2419200 300
+@  NS  ns1-01.azure-dns.com.
+@  A   10.0.0.1
+`
+
+	recordSets, err := parseArmDnsZoneRecordsZoneFile(input, "example.com", 3600)
+	if err != nil {
+		t.Fatalf("Expected no error parsing the zone file but got: %+v", err)
+	}
+
+	if len(recordSets) != 1 || recordSets[0].Key() != "@/A" {
+		t.Fatalf("Expected only the `@/A` Record Set but got %+v", recordSets)
+	}
+}
+
+func TestAccAzureRMDnsZoneRecords_basic(t *testing.T) {
+	resourceName := "azurerm_dns_zone_records.test"
+	ri := tf.AccRandTimeInt()
+	config := testAccAzureRMDnsZoneRecords_basic(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMDnsZoneRecordsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMDnsZoneRecordsExists(resourceName, 4),
+					resource.TestCheckResourceAttr(resourceName, "record_set.#", "4"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"zone_file", "default_ttl"},
+			},
+		},
+	})
+}
+
+func TestAccAzureRMDnsZoneRecords_update(t *testing.T) {
+	resourceName := "azurerm_dns_zone_records.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMDnsZoneRecordsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMDnsZoneRecords_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMDnsZoneRecordsExists(resourceName, 4),
+					resource.TestCheckResourceAttr(resourceName, "record_set.#", "4"),
+				),
+			},
+			{
+				Config: testAccAzureRMDnsZoneRecords_updated(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMDnsZoneRecordsExists(resourceName, 6),
+					resource.TestCheckResourceAttr(resourceName, "record_set.#", "6"),
+				),
+			},
+			{
+				Config: testAccAzureRMDnsZoneRecords_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMDnsZoneRecordsExists(resourceName, 4),
+					resource.TestCheckResourceAttr(resourceName, "record_set.#", "4"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMDnsZoneRecordsExists(resourceName string, expectedCount int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// Ensure we have enough information in state to look up in API
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		zoneName := rs.Primary.Attributes["zone_name"]
+		resourceGroup, hasResourceGroup := rs.Primary.Attributes["resource_group_name"]
+		if !hasResourceGroup {
+			return fmt.Errorf("Bad: no resource group found in state for DNS Zone Records: %s", zoneName)
+		}
+
+		recordSets, _, err := retrieveArmDnsZoneRecordSets(testAccProvider.Meta(), resourceGroup, zoneName)
+		if err != nil {
+			return err
+		}
+
+		if len(recordSets) != expectedCount {
+			return fmt.Errorf("Bad: expected %d Record Sets in DNS Zone %q (Resource Group %q) but got %d", expectedCount, zoneName, resourceGroup, len(recordSets))
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMDnsZoneRecordsDestroy(s *terraform.State) error {
+	zonesClient := testAccProvider.Meta().(*ArmClient).zonesClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_dns_zone_records" {
+			continue
+		}
+
+		zoneName := rs.Primary.Attributes["zone_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := zonesClient.Get(ctx, resourceGroup, zoneName)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return nil
+			}
+
+			return err
+		}
+
+		recordSets, _, err := retrieveArmDnsZoneRecordSets(testAccProvider.Meta(), resourceGroup, zoneName)
+		if err != nil {
+			return err
+		}
+
+		if len(recordSets) > 0 {
+			return fmt.Errorf("DNS Zone %q (Resource Group %q) still contains %d Record Sets", zoneName, resourceGroup, len(recordSets))
+		}
+	}
+
+	return nil
+}
+
+func testAccAzureRMDnsZoneRecords_basic(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_dns_zone" "test" {
+  name                = "acctestzone%d.com"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_dns_zone_records" "test" {
+  zone_name           = "${azurerm_dns_zone.test.name}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  zone_file = <<ZONE
+$TTL 300
+@       MX     10 mail
+@       TXT    "v=spf1 -all"
+www     A      10.0.0.1
+        A      10.0.0.2
+ftp     CNAME  www
+ZONE
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMDnsZoneRecords_updated(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_dns_zone" "test" {
+  name                = "acctestzone%d.com"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_dns_zone_records" "test" {
+  zone_name           = "${azurerm_dns_zone.test.name}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  zone_file = <<ZONE
+$TTL 600
+@       MX     10 mail
+        MX     20 mail.backup.example.net.
+@       TXT    "v=spf1 -all"
+www     A      10.0.0.1
+ftp     A      10.0.0.3
+ipv6    AAAA   2001:db8::1
+_sip._tcp 3600 SRV 10 60 5060 sip
+ZONE
+}
+`, rInt, location, rInt)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
@@ -36,21 +37,38 @@ func resourceArmExpressRouteCircuit() *schema.Resource {
 
 			"service_provider_name": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
 				ForceNew:         true,
 				DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+				ConflictsWith:    []string{"express_route_port_id", "bandwidth_in_gbps"},
 			},
 
 			"peering_location": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
 				ForceNew:         true,
 				DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+				ConflictsWith:    []string{"express_route_port_id", "bandwidth_in_gbps"},
 			},
 
 			"bandwidth_in_mbps": {
-				Type:     schema.TypeInt,
-				Required: true,
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"express_route_port_id", "bandwidth_in_gbps"},
+			},
+
+			"express_route_port_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  azure.ValidateResourceID,
+				ConflictsWith: []string{"service_provider_name", "peering_location", "bandwidth_in_mbps"},
+			},
+
+			"bandwidth_in_gbps": {
+				Type:          schema.TypeFloat,
+				Optional:      true,
+				ConflictsWith: []string{"service_provider_name", "peering_location", "bandwidth_in_mbps"},
 			},
 
 			"sku": {
@@ -127,9 +145,6 @@ func resourceArmExpressRouteCircuitCreateUpdate(d *schema.ResourceData, meta int
 	}
 
 	location := azureRMNormalizeLocation(d.Get("location").(string))
-	serviceProviderName := d.Get("service_provider_name").(string)
-	peeringLocation := d.Get("peering_location").(string)
-	bandwidthInMbps := int32(d.Get("bandwidth_in_mbps").(int))
 	sku := expandExpressRouteCircuitSku(d)
 	allowRdfeOps := d.Get("allow_classic_operations").(bool)
 	tags := d.Get("tags").(map[string]interface{})
@@ -141,15 +156,36 @@ func resourceArmExpressRouteCircuitCreateUpdate(d *schema.ResourceData, meta int
 		Sku:      sku,
 		ExpressRouteCircuitPropertiesFormat: &network.ExpressRouteCircuitPropertiesFormat{
 			AllowClassicOperations: &allowRdfeOps,
-			ServiceProviderProperties: &network.ExpressRouteCircuitServiceProviderProperties{
-				ServiceProviderName: &serviceProviderName,
-				PeeringLocation:     &peeringLocation,
-				BandwidthInMbps:     &bandwidthInMbps,
-			},
 		},
 		Tags: expandedTags,
 	}
 
+	// circuits are either provisioned through a Service Provider or directly on an ExpressRoute Port
+	if portId := d.Get("express_route_port_id").(string); portId != "" {
+		bandwidthInGbps := d.Get("bandwidth_in_gbps").(float64)
+		if bandwidthInGbps == 0 {
+			return fmt.Errorf("`bandwidth_in_gbps` must be specified when `express_route_port_id` is specified")
+		}
+
+		erc.ExpressRouteCircuitPropertiesFormat.ExpressRoutePort = &network.SubResource{
+			ID: utils.String(portId),
+		}
+		erc.ExpressRouteCircuitPropertiesFormat.BandwidthInGbps = utils.Float(bandwidthInGbps)
+	} else {
+		serviceProviderName := d.Get("service_provider_name").(string)
+		peeringLocation := d.Get("peering_location").(string)
+		bandwidthInMbps := int32(d.Get("bandwidth_in_mbps").(int))
+		if serviceProviderName == "" || peeringLocation == "" || bandwidthInMbps == 0 {
+			return fmt.Errorf("`service_provider_name`, `peering_location` and `bandwidth_in_mbps` must be specified when `express_route_port_id` isn't specified")
+		}
+
+		erc.ExpressRouteCircuitPropertiesFormat.ServiceProviderProperties = &network.ExpressRouteCircuitServiceProviderProperties{
+			ServiceProviderName: &serviceProviderName,
+			PeeringLocation:     &peeringLocation,
+			BandwidthInMbps:     &bandwidthInMbps,
+		}
+	}
+
 	azureRMLockByName(name, expressRouteCircuitResourceName)
 	defer azureRMUnlockByName(name, expressRouteCircuitResourceName)
 
@@ -206,6 +242,13 @@ func resourceArmExpressRouteCircuitRead(d *schema.ResourceData, meta interface{}
 		d.Set("bandwidth_in_mbps", props.BandwidthInMbps)
 	}
 
+	expressRoutePortId := ""
+	if port := resp.ExpressRoutePort; port != nil && port.ID != nil {
+		expressRoutePortId = *port.ID
+	}
+	d.Set("express_route_port_id", expressRoutePortId)
+	d.Set("bandwidth_in_gbps", resp.BandwidthInGbps)
+
 	d.Set("service_provider_provisioning_state", string(resp.ServiceProviderProvisioningState))
 	d.Set("service_key", resp.ServiceKey)
 	d.Set("allow_classic_operations", resp.AllowClassicOperations)
@@ -8,8 +8,10 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
@@ -86,6 +88,54 @@ func resourceArmExpressRouteCircuitPeering() *schema.Resource {
 				},
 			},
 
+			"route_filter_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"ipv6": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"primary_peer_address_prefix": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.CIDRv6,
+						},
+
+						"secondary_peer_address_prefix": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.CIDRv6,
+						},
+
+						"microsoft_peering_config": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"advertised_public_prefixes": {
+										Type:     schema.TypeList,
+										Required: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+
+						"route_filter_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+					},
+				},
+			},
+
 			"azure_asn": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -154,6 +204,22 @@ func resourceArmExpressRouteCircuitPeeringCreateUpdate(d *schema.ResourceData, m
 
 		peeringConfig := expandExpressRouteCircuitPeeringMicrosoftConfig(peerings)
 		parameters.ExpressRouteCircuitPeeringPropertiesFormat.MicrosoftPeeringConfig = peeringConfig
+
+		if routeFilterId := d.Get("route_filter_id").(string); routeFilterId != "" {
+			parameters.ExpressRouteCircuitPeeringPropertiesFormat.RouteFilter = &network.RouteFilter{
+				ID: utils.String(routeFilterId),
+			}
+		}
+	} else if d.Get("route_filter_id").(string) != "" {
+		return fmt.Errorf("`route_filter_id` can only be specified when `peering_type` is set to `MicrosoftPeering`")
+	}
+
+	if v := d.Get("ipv6").([]interface{}); len(v) > 0 {
+		ipv6Config, err := expandExpressRouteCircuitPeeringIpv6Config(v, peeringType)
+		if err != nil {
+			return err
+		}
+		parameters.ExpressRouteCircuitPeeringPropertiesFormat.Ipv6PeeringConfig = ipv6Config
 	}
 
 	azureRMLockByName(circuitName, expressRouteCircuitResourceName)
@@ -216,6 +282,16 @@ func resourceArmExpressRouteCircuitPeeringRead(d *schema.ResourceData, meta inte
 		if err := d.Set("microsoft_peering_config", config); err != nil {
 			return fmt.Errorf("Error setting `microsoft_peering_config`: %+v", err)
 		}
+
+		routeFilterId := ""
+		if props.RouteFilter != nil && props.RouteFilter.ID != nil {
+			routeFilterId = *props.RouteFilter.ID
+		}
+		d.Set("route_filter_id", routeFilterId)
+
+		if err := d.Set("ipv6", flattenExpressRouteCircuitPeeringIpv6Config(props.Ipv6PeeringConfig)); err != nil {
+			return fmt.Errorf("Error setting `ipv6`: %+v", err)
+		}
 	}
 
 	return nil
@@ -284,3 +360,63 @@ func flattenExpressRouteCircuitPeeringMicrosoftConfig(input *network.ExpressRout
 
 	return []interface{}{config}
 }
+
+func expandExpressRouteCircuitPeeringIpv6Config(input []interface{}, peeringType string) (*network.Ipv6ExpressRouteCircuitPeeringConfig, error) {
+	v := input[0].(map[string]interface{})
+
+	config := network.Ipv6ExpressRouteCircuitPeeringConfig{
+		PrimaryPeerAddressPrefix:   utils.String(v["primary_peer_address_prefix"].(string)),
+		SecondaryPeerAddressPrefix: utils.String(v["secondary_peer_address_prefix"].(string)),
+	}
+
+	microsoftPeering := strings.EqualFold(peeringType, string(network.MicrosoftPeering))
+	peeringConfig := v["microsoft_peering_config"].([]interface{})
+	routeFilterId := v["route_filter_id"].(string)
+
+	if microsoftPeering {
+		if len(peeringConfig) == 0 {
+			return nil, fmt.Errorf("`ipv6.0.microsoft_peering_config` must be specified when `peering_type` is set to `MicrosoftPeering`")
+		}
+		config.MicrosoftPeeringConfig = expandExpressRouteCircuitPeeringMicrosoftConfig(peeringConfig)
+
+		if routeFilterId != "" {
+			config.RouteFilter = &network.RouteFilter{
+				ID: utils.String(routeFilterId),
+			}
+		}
+	} else if len(peeringConfig) > 0 || routeFilterId != "" {
+		return nil, fmt.Errorf("`ipv6.0.microsoft_peering_config` and `ipv6.0.route_filter_id` can only be specified when `peering_type` is set to `MicrosoftPeering`")
+	}
+
+	return &config, nil
+}
+
+func flattenExpressRouteCircuitPeeringIpv6Config(input *network.Ipv6ExpressRouteCircuitPeeringConfig) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	primaryPeerAddressPrefix := ""
+	if input.PrimaryPeerAddressPrefix != nil {
+		primaryPeerAddressPrefix = *input.PrimaryPeerAddressPrefix
+	}
+
+	secondaryPeerAddressPrefix := ""
+	if input.SecondaryPeerAddressPrefix != nil {
+		secondaryPeerAddressPrefix = *input.SecondaryPeerAddressPrefix
+	}
+
+	routeFilterId := ""
+	if input.RouteFilter != nil && input.RouteFilter.ID != nil {
+		routeFilterId = *input.RouteFilter.ID
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"primary_peer_address_prefix":   primaryPeerAddressPrefix,
+			"secondary_peer_address_prefix": secondaryPeerAddressPrefix,
+			"microsoft_peering_config":      flattenExpressRouteCircuitPeeringMicrosoftConfig(input.MicrosoftPeeringConfig),
+			"route_filter_id":               routeFilterId,
+		},
+	}
+}
@@ -0,0 +1,373 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmExpressRoutePort() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmExpressRoutePortCreateUpdate,
+		Read:   resourceArmExpressRoutePortRead,
+		Update: resourceArmExpressRoutePortCreateUpdate,
+		Delete: resourceArmExpressRoutePortDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"location": locationSchema(),
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"peering_location": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validate.NoEmptyStrings,
+				DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+			},
+
+			"bandwidth_in_gbps": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntInSlice([]int{10, 100}),
+			},
+
+			"encapsulation": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(network.Dot1Q),
+					string(network.QinQ),
+				}, false),
+			},
+
+			"admin_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"ethertype": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"mtu": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"provisioned_bandwidth_in_gbps": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+
+			"link": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"router_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"interface_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"patch_panel_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"rack_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"connector_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"admin_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceArmExpressRoutePortCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).expressRoutePortsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	log.Printf("[INFO] preparing arguments for AzureRM ExpressRoute Port creation.")
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	existing, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("Error checking for presence of existing ExpressRoute Port %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_express_route_port", *existing.ID)
+		}
+	}
+
+	location := azureRMNormalizeLocation(d.Get("location").(string))
+	peeringLocation := d.Get("peering_location").(string)
+	bandwidthInGbps := d.Get("bandwidth_in_gbps").(int)
+	encapsulation := d.Get("encapsulation").(string)
+	adminEnabled := d.Get("admin_enabled").(bool)
+	tags := d.Get("tags").(map[string]interface{})
+
+	port := network.ExpressRoutePort{
+		Location: utils.String(location),
+		ExpressRoutePortPropertiesFormat: &network.ExpressRoutePortPropertiesFormat{
+			PeeringLocation: utils.String(peeringLocation),
+			BandwidthInGbps: utils.Int32(int32(bandwidthInGbps)),
+			Encapsulation:   network.ExpressRoutePortsEncapsulation(encapsulation),
+		},
+		Tags: expandTags(tags),
+	}
+
+	// the links are allocated by Azure when the port is created, so can only be enabled once they exist
+	if props := existing.ExpressRoutePortPropertiesFormat; props != nil && props.Links != nil {
+		port.ExpressRoutePortPropertiesFormat.Links = expandArmExpressRoutePortLinks(*props.Links, adminEnabled)
+	}
+
+	if err := createOrUpdateArmExpressRoutePort(d, meta, port); err != nil {
+		return err
+	}
+
+	read, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving ExpressRoute Port %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read ExpressRoute Port %q (Resource Group %q) ID", name, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	if port.ExpressRoutePortPropertiesFormat.Links == nil && adminEnabled {
+		if props := read.ExpressRoutePortPropertiesFormat; props != nil && props.Links != nil {
+			port.ExpressRoutePortPropertiesFormat.Links = expandArmExpressRoutePortLinks(*props.Links, adminEnabled)
+
+			if err := createOrUpdateArmExpressRoutePort(d, meta, port); err != nil {
+				return err
+			}
+		}
+	}
+
+	return resourceArmExpressRoutePortRead(d, meta)
+}
+
+func createOrUpdateArmExpressRoutePort(d *schema.ResourceData, meta interface{}, port network.ExpressRoutePort) error {
+	client := meta.(*ArmClient).expressRoutePortsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, name, port)
+	if err != nil {
+		return fmt.Errorf("Error Creating/Updating ExpressRoute Port %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for completion of ExpressRoute Port %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	return nil
+}
+
+func resourceArmExpressRoutePortRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).expressRoutePortsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["expressRoutePorts"]
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] ExpressRoute Port %q does not exist - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error making Read request on ExpressRoute Port %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+	if location := resp.Location; location != nil {
+		d.Set("location", azureRMNormalizeLocation(*location))
+	}
+
+	if props := resp.ExpressRoutePortPropertiesFormat; props != nil {
+		d.Set("peering_location", props.PeeringLocation)
+		d.Set("bandwidth_in_gbps", props.BandwidthInGbps)
+		d.Set("encapsulation", string(props.Encapsulation))
+		d.Set("ethertype", props.EtherType)
+		d.Set("mtu", props.Mtu)
+		d.Set("provisioned_bandwidth_in_gbps", props.ProvisionedBandwidthInGbps)
+
+		links := flattenArmExpressRoutePortLinks(props.Links)
+		if err := d.Set("link", links); err != nil {
+			return fmt.Errorf("Error setting `link`: %+v", err)
+		}
+
+		adminEnabled := len(links) > 0
+		for _, link := range links {
+			if !link.(map[string]interface{})["admin_enabled"].(bool) {
+				adminEnabled = false
+			}
+		}
+		d.Set("admin_enabled", adminEnabled)
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmExpressRoutePortDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).expressRoutePortsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["expressRoutePorts"]
+
+	future, err := client.Delete(ctx, resourceGroup, name)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting ExpressRoute Port %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for deletion of ExpressRoute Port %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func expandArmExpressRoutePortLinks(input []network.ExpressRouteLink, adminEnabled bool) *[]network.ExpressRouteLink {
+	adminState := network.ExpressRouteLinkAdminStateDisabled
+	if adminEnabled {
+		adminState = network.ExpressRouteLinkAdminStateEnabled
+	}
+
+	links := make([]network.ExpressRouteLink, 0)
+	for _, link := range input {
+		links = append(links, network.ExpressRouteLink{
+			Name: link.Name,
+			ExpressRouteLinkPropertiesFormat: &network.ExpressRouteLinkPropertiesFormat{
+				AdminState: adminState,
+			},
+		})
+	}
+
+	return &links
+}
+
+func flattenArmExpressRoutePortLinks(input *[]network.ExpressRouteLink) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, link := range *input {
+		result := map[string]interface{}{
+			"admin_enabled": false,
+		}
+
+		if link.ID != nil {
+			result["id"] = *link.ID
+		}
+
+		if link.Name != nil {
+			result["name"] = *link.Name
+		}
+
+		if props := link.ExpressRouteLinkPropertiesFormat; props != nil {
+			result["admin_enabled"] = props.AdminState == network.ExpressRouteLinkAdminStateEnabled
+			result["connector_type"] = string(props.ConnectorType)
+
+			if props.RouterName != nil {
+				result["router_name"] = *props.RouterName
+			}
+
+			if props.InterfaceName != nil {
+				result["interface_name"] = *props.InterfaceName
+			}
+
+			if props.PatchPanelID != nil {
+				result["patch_panel_id"] = *props.PatchPanelID
+			}
+
+			if props.RackID != nil {
+				result["rack_id"] = *props.RackID
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
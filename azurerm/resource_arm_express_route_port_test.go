@@ -0,0 +1,154 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// NOTE: these tests aren't run in parallel since ExpressRoute Direct Ports are a limited resource
+// and the subscription must be enrolled in ExpressRoute Direct
+
+func TestAccAzureRMExpressRoutePort_basic(t *testing.T) {
+	resourceName := "azurerm_express_route_port.test"
+	ri := tf.AccRandTimeInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMExpressRoutePortDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMExpressRoutePort_basic(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMExpressRoutePortExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "bandwidth_in_gbps", "10"),
+					resource.TestCheckResourceAttr(resourceName, "encapsulation", "Dot1Q"),
+					resource.TestCheckResourceAttr(resourceName, "link.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMExpressRoutePort_circuit(t *testing.T) {
+	ri := tf.AccRandTimeInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMExpressRoutePortDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMExpressRoutePort_circuit(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMExpressRoutePortExists("azurerm_express_route_port.test"),
+					resource.TestCheckResourceAttrPair("azurerm_express_route_circuit.test", "express_route_port_id", "azurerm_express_route_port.test", "id"),
+					resource.TestCheckResourceAttr("azurerm_express_route_circuit.test", "bandwidth_in_gbps", "5"),
+				),
+			},
+			{
+				ResourceName:      "azurerm_express_route_circuit.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckAzureRMExpressRoutePortExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).expressRoutePortsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: ExpressRoute Port %q (Resource Group %q) does not exist", name, resourceGroup)
+			}
+			return fmt.Errorf("Bad: Get on expressRoutePortsClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMExpressRoutePortDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).expressRoutePortsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_express_route_port" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return nil
+			}
+			return err
+		}
+
+		return fmt.Errorf("ExpressRoute Port still exists:\n%#v", resp.ExpressRoutePortPropertiesFormat)
+	}
+
+	return nil
+}
+
+func testAccAzureRMExpressRoutePort_basic(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_express_route_port" "test" {
+  name                = "acctesterp%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  peering_location    = "Airtel-Chennai2-CLS"
+  bandwidth_in_gbps   = 10
+  encapsulation       = "Dot1Q"
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMExpressRoutePort_circuit(rInt int, location string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_express_route_circuit" "test" {
+  name                  = "acctest-erc-%d"
+  location              = "${azurerm_resource_group.test.location}"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  express_route_port_id = "${azurerm_express_route_port.test.id}"
+  bandwidth_in_gbps     = 5
+
+  sku {
+    tier   = "Standard"
+    family = "MeteredData"
+  }
+}
+`, testAccAzureRMExpressRoutePort_basic(rInt, location), rInt)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
@@ -48,6 +49,25 @@ func resourceArmImage() *schema.Resource {
 				ValidateFunc: azure.ValidateResourceID,
 			},
 
+			"generalize_source_virtual_machine": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"delete_source_virtual_machine": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// the error from deleting the source Virtual Machine after the Image was captured, which is
+			// shown in (and retried by) the next plan, since returning it from Create would taint the Image
+			"source_virtual_machine_deletion_error": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"os_disk": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -162,7 +182,18 @@ func resourceArmImage() *schema.Resource {
 
 			"tags": tagsSchema(),
 		},
+
+		CustomizeDiff: resourceArmImageCustomizeDiff,
+	}
+}
+
+func resourceArmImageCustomizeDiff(d *schema.ResourceDiff, v interface{}) error {
+	// clearing the error means it's shown in the plan - and the deletion is retried if it's still enabled
+	if d.Id() != "" && d.Get("source_virtual_machine_deletion_error").(string) != "" {
+		return d.SetNew("source_virtual_machine_deletion_error", "")
 	}
+
+	return nil
 }
 
 func resourceArmImageCreateUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -217,6 +248,12 @@ func resourceArmImageCreateUpdate(d *schema.ResourceData, meta interface{}) erro
 		}
 	}
 
+	generalizeSourceVM := d.Get("generalize_source_virtual_machine").(bool)
+	deleteSourceVM := d.Get("delete_source_virtual_machine").(bool)
+	if sourceVM.ID == nil && (generalizeSourceVM || deleteSourceVM) {
+		return fmt.Errorf("[ERROR] `source_virtual_machine_id` must be specified when `generalize_source_virtual_machine` or `delete_source_virtual_machine` are set")
+	}
+
 	//either source VM or storage profile can be specified, but not both
 	if sourceVM.ID == nil {
 		//if both sourceVM and storageProfile are empty, return an error
@@ -241,6 +278,14 @@ func resourceArmImageCreateUpdate(d *schema.ResourceData, meta interface{}) erro
 		ImageProperties: &properties,
 	}
 
+	// the source Virtual Machine is only generalized/deleted when the Image is first created
+	captureSourceVM := d.IsNewResource() && sourceVM.ID != nil
+	if captureSourceVM && generalizeSourceVM {
+		if err := generalizeAzureRmImageSourceVirtualMachine(meta, *sourceVM.ID); err != nil {
+			return err
+		}
+	}
+
 	future, err := client.CreateOrUpdate(ctx, resGroup, name, createImage)
 	if err != nil {
 		return err
@@ -260,9 +305,93 @@ func resourceArmImageCreateUpdate(d *schema.ResourceData, meta interface{}) erro
 
 	d.SetId(*read.ID)
 
+	retryDeleteSourceVM := !d.IsNewResource() && d.HasChange("source_virtual_machine_deletion_error")
+	if sourceVM.ID != nil && deleteSourceVM && (captureSourceVM || retryDeleteSourceVM) {
+		if err := deleteAzureRmImageSourceVirtualMachine(meta, *sourceVM.ID); err != nil {
+			if retryDeleteSourceVM {
+				// only the error is persisted, so that the deletion is retried again during the next apply
+				d.Partial(true)
+				d.Set("source_virtual_machine_deletion_error", err.Error())
+				d.SetPartial("source_virtual_machine_deletion_error")
+				return fmt.Errorf("Error deleting the source Virtual Machine %q for Image %q (Resource Group %q): %+v", *sourceVM.ID, name, resGroup, err)
+			}
+
+			// the Image has been captured at this point - returning an error would taint it and cause it to be recreated
+			// from a Virtual Machine which has already been generalized, so the error is shown and retried in the next plan
+			log.Printf("[WARN] Image %q (Resource Group %q) was captured but the source Virtual Machine %q couldn't be deleted: %+v", name, resGroup, *sourceVM.ID, err)
+			d.Set("source_virtual_machine_deletion_error", err.Error())
+		}
+	}
+
 	return resourceArmImageRead(d, meta)
 }
 
+// generalizeAzureRmImageSourceVirtualMachine deallocates and then generalizes the Virtual Machine, so that it can be captured
+// NOTE: the Virtual Machine must have been deprovisioned/sysprep'd from within the Guest OS prior to this being called
+func generalizeAzureRmImageSourceVirtualMachine(meta interface{}, virtualMachineId string) error {
+	client := meta.(*ArmClient).vmClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(virtualMachineId)
+	if err != nil {
+		return err
+	}
+	resGroup := id.ResourceGroup
+	name := id.Path["virtualMachines"]
+
+	azureRMLockByName(name, virtualMachineResourceName)
+	defer azureRMUnlockByName(name, virtualMachineResourceName)
+
+	log.Printf("[INFO] Capturing Image from Virtual Machine %q (Resource Group %q) - step 1/3: deallocating..", name, resGroup)
+	future, err := client.Deallocate(ctx, resGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error deallocating Virtual Machine %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for deallocation of Virtual Machine %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	log.Printf("[INFO] Capturing Image from Virtual Machine %q (Resource Group %q) - step 2/3: generalizing..", name, resGroup)
+	if _, err := client.Generalize(ctx, resGroup, name); err != nil {
+		return fmt.Errorf("Error generalizing Virtual Machine %q (Resource Group %q) - the Virtual Machine has been deallocated but not generalized: %+v", name, resGroup, err)
+	}
+
+	log.Printf("[INFO] Capturing Image from Virtual Machine %q (Resource Group %q) - step 3/3: creating the Image..", name, resGroup)
+	return nil
+}
+
+func deleteAzureRmImageSourceVirtualMachine(meta interface{}, virtualMachineId string) error {
+	client := meta.(*ArmClient).vmClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(virtualMachineId)
+	if err != nil {
+		return err
+	}
+	resGroup := id.ResourceGroup
+	name := id.Path["virtualMachines"]
+
+	azureRMLockByName(name, virtualMachineResourceName)
+	defer azureRMUnlockByName(name, virtualMachineResourceName)
+
+	log.Printf("[INFO] Deleting source Virtual Machine %q (Resource Group %q)..", name, resGroup)
+	future, err := client.Delete(ctx, resGroup, name)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Virtual Machine %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for deletion of Virtual Machine %q (Resource Group %q): %+v", name, resGroup, err)
+		}
+	}
+
+	log.Printf("[INFO] Deleted source Virtual Machine %q (Resource Group %q).", name, resGroup)
+	return nil
+}
+
 func resourceArmImageRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).imageClient
 	ctx := meta.(*ArmClient).StopContext
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -46,9 +47,10 @@ func TestAccAzureRMImage_standaloneImage(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      "azurerm_image.test",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "azurerm_image.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"generalize_source_virtual_machine", "delete_source_virtual_machine"},
 			},
 		},
 	})
@@ -86,9 +88,10 @@ func TestAccAzureRMImage_standaloneImageZoneRedundant(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      "azurerm_image.test",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "azurerm_image.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"generalize_source_virtual_machine", "delete_source_virtual_machine"},
 			},
 		},
 	})
@@ -206,6 +209,83 @@ func TestAccAzureRMImage_customImageVMFromVM(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMImage_customImageVMFromVMGeneralize(t *testing.T) {
+	resourceName := "azurerm_image.test"
+	ri := tf.AccRandTimeInt()
+	userName := "testadmin"
+	password := "Password1234!"
+	hostName := fmt.Sprintf("tftestcustomimagesrc%d", ri)
+	sshPort := "22"
+	location := testLocation()
+	preConfig := testAccAzureRMImage_customImage_fromVM_sourceVM(ri, userName, password, hostName, location)
+	postConfig := testAccAzureRMImage_customImage_fromVM_generalize(ri, userName, password, hostName, location)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMImageDestroy,
+		Steps: []resource.TestStep{
+			{
+				// the VM needs to be deprovisioned from within the Guest OS - the image resource generalizes it
+				Config:  preConfig,
+				Destroy: false,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureVMExists("azurerm_virtual_machine.testsource", true),
+					testDeprovisionVMImage(userName, password, hostName, sshPort, location),
+				),
+			},
+			{
+				Config: postConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMImageExists(resourceName, true),
+					resource.TestCheckResourceAttr(resourceName, "generalize_source_virtual_machine", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMImage_customImageVMFromVMGeneralizeAndDelete(t *testing.T) {
+	resourceName := "azurerm_image.test"
+	ri := tf.AccRandTimeInt()
+	userName := "testadmin"
+	password := "Password1234!"
+	hostName := fmt.Sprintf("tftestcustomimagesrc%d", ri)
+	sshPort := "22"
+	location := testLocation()
+	preConfig := testAccAzureRMImage_customImage_fromVM_sourceVM(ri, userName, password, hostName, location)
+	postConfig := testAccAzureRMImage_customImage_fromVM_generalizeAndDelete(ri, userName, password, hostName, location)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMImageDestroy,
+		Steps: []resource.TestStep{
+			{
+				// the VM needs to be deprovisioned from within the Guest OS - the image resource generalizes it
+				Config:  preConfig,
+				Destroy: false,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureVMExists("azurerm_virtual_machine.testsource", true),
+					testDeprovisionVMImage(userName, password, hostName, sshPort, location),
+				),
+			},
+			{
+				Config: postConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMImageExists(resourceName, true),
+					resource.TestCheckResourceAttr(resourceName, "delete_source_virtual_machine", "true"),
+					resource.TestCheckResourceAttr(resourceName, "source_virtual_machine_deletion_error", ""),
+					testCheckAzureVMExists("azurerm_virtual_machine.testsource", false),
+				),
+				// the source Virtual Machine is still in the configuration, but has been deleted by the Image - so the
+				// next plan recreates it (as documented for `delete_source_virtual_machine`)
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func TestAccAzureRMImageVMSS_customImageVMSSFromVHD(t *testing.T) {
 	ri := tf.AccRandTimeInt()
 	resourceGroup := fmt.Sprintf("acctestRG-%d", ri)
@@ -272,6 +352,22 @@ func testGeneralizeVMImage(resourceGroup string, vmName string, userName string,
 	}
 }
 
+func testDeprovisionVMImage(userName string, password string, hostName string, port string, location string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		armClient := testAccProvider.Meta().(*ArmClient)
+
+		normalizedLocation := azureRMNormalizeLocation(location)
+		suffix := armClient.environment.ResourceManagerVMDNSSuffix
+		dnsName := fmt.Sprintf("%s.%s.%s", hostName, normalizedLocation, suffix)
+
+		if err := deprovisionVM(userName, password, dnsName, port); err != nil {
+			return fmt.Errorf("Bad: Deprovisioning error %+v", err)
+		}
+
+		return nil
+	}
+}
+
 func deprovisionVM(userName string, password string, hostName string, port string) error {
 	//SSH into the machine and execute a waagent deprovisioning command
 	var b bytes.Buffer
@@ -359,7 +455,7 @@ func testCheckAzureVMExists(sourceVM string, shouldExist bool) resource.TestChec
 		}
 
 		resp, err := client.Get(ctx, resourceGroup, vmName, "")
-		if err != nil {
+		if err != nil && !utils.ResponseWasNotFound(resp.Response) {
 			return fmt.Errorf("Bad: Get on client: %+v", err)
 		}
 
@@ -1169,6 +1265,37 @@ resource "azurerm_virtual_machine" "testdestination" {
 `, rInt, location, rInt, rInt, rInt, hostName, rInt, userName, password, rInt, rInt, userName, password)
 }
 
+func testAccAzureRMImage_customImage_fromVM_generalize(rInt int, userName string, password string, hostName string, location string) string {
+	template := testAccAzureRMImage_customImage_fromVM_sourceVM(rInt, userName, password, hostName, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_image" "test" {
+  name                              = "acctest-%d"
+  location                          = "${azurerm_resource_group.test.location}"
+  resource_group_name               = "${azurerm_resource_group.test.name}"
+  source_virtual_machine_id         = "${azurerm_virtual_machine.testsource.id}"
+  generalize_source_virtual_machine = true
+}
+`, template, rInt)
+}
+
+func testAccAzureRMImage_customImage_fromVM_generalizeAndDelete(rInt int, userName string, password string, hostName string, location string) string {
+	template := testAccAzureRMImage_customImage_fromVM_sourceVM(rInt, userName, password, hostName, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_image" "test" {
+  name                              = "acctest-%d"
+  location                          = "${azurerm_resource_group.test.location}"
+  resource_group_name               = "${azurerm_resource_group.test.name}"
+  source_virtual_machine_id         = "${azurerm_virtual_machine.testsource.id}"
+  generalize_source_virtual_machine = true
+  delete_source_virtual_machine     = true
+}
+`, template, rInt)
+}
+
 func testAccAzureRMImageVMSS_customImage_fromVHD_setup(rInt int, userName string, password string, hostName string, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
@@ -2,11 +2,12 @@ package azurerm
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2018-03-31/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2019-02-01/containerservice"
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
@@ -18,6 +19,8 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+var kubernetesClusterResourceName = "azurerm_kubernetes_cluster"
+
 func resourceArmKubernetesCluster() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmKubernetesClusterCreateUpdate,
@@ -28,40 +31,7 @@ func resourceArmKubernetesCluster() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
-		CustomizeDiff: func(diff *schema.ResourceDiff, v interface{}) error {
-			if v, exists := diff.GetOk("network_profile"); exists {
-				rawProfiles := v.([]interface{})
-				if len(rawProfiles) == 0 {
-					return nil
-				}
-
-				// then ensure the conditionally-required fields are set
-				profile := rawProfiles[0].(map[string]interface{})
-				networkPlugin := profile["network_plugin"].(string)
-
-				if networkPlugin != "kubenet" && networkPlugin != "azure" {
-					return nil
-				}
-
-				dockerBridgeCidr := profile["docker_bridge_cidr"].(string)
-				dnsServiceIP := profile["dns_service_ip"].(string)
-				serviceCidr := profile["service_cidr"].(string)
-
-				// All empty values.
-				if dockerBridgeCidr == "" && dnsServiceIP == "" && serviceCidr == "" {
-					return nil
-				}
-
-				// All set values.
-				if dockerBridgeCidr != "" && dnsServiceIP != "" && serviceCidr != "" {
-					return nil
-				}
-
-				return fmt.Errorf("`docker_bridge_cidr`, `dns_service_ip` and `service_cidr` should all be empty or all should be set.")
-			}
-
-			return nil
-		},
+		CustomizeDiff: resourceArmKubernetesClusterCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -163,6 +133,17 @@ func resourceArmKubernetesCluster() *schema.Resource {
 							Computed: true,
 							ForceNew: true,
 						},
+
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Default:  string(containerservice.AvailabilitySet),
+							ValidateFunc: validation.StringInSlice([]string{
+								string(containerservice.AvailabilitySet),
+								string(containerservice.VirtualMachineScaleSets),
+							}, false),
+						},
 					},
 				},
 			},
@@ -181,9 +162,9 @@ func resourceArmKubernetesCluster() *schema.Resource {
 							ValidateFunc: validate.NoEmptyStrings,
 						},
 
+						// rotated in-place via the Reset Service Principal Profile API
 						"client_secret": {
 							Type:         schema.TypeString,
-							ForceNew:     true,
 							Required:     true,
 							Sensitive:    true,
 							ValidateFunc: validate.NoEmptyStrings,
@@ -194,6 +175,16 @@ func resourceArmKubernetesCluster() *schema.Resource {
 			},
 
 			// Optional
+			"api_server_authorized_ip_ranges": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.CIDR,
+				},
+				Set: schema.HashString,
+			},
+
 			"addon_profile": {
 				Type:     schema.TypeList,
 				MaxItems: 1,
@@ -319,7 +310,7 @@ func resourceArmKubernetesCluster() *schema.Resource {
 							Computed: true,
 							ForceNew: true,
 							ValidateFunc: validation.StringInSlice([]string{
-								string(containerservice.Calico),
+								string(containerservice.NetworkPolicyCalico),
 							}, false),
 						},
 
@@ -507,6 +498,43 @@ func resourceArmKubernetesCluster() *schema.Resource {
 				Sensitive: true,
 			},
 
+			"kube_config_exec": {
+				Type:     schema.TypeList,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cluster_ca_certificate": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"api_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"command": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"args": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"kube_config_exec_raw": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
 			"node_resource_group": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -515,6 +543,103 @@ func resourceArmKubernetesCluster() *schema.Resource {
 	}
 }
 
+func resourceArmKubernetesClusterCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+	if err := validateKubernetesClusterNetworkProfileDiff(diff); err != nil {
+		return err
+	}
+
+	return validateKubernetesClusterVersionUpgradeDiff(diff, v)
+}
+
+func validateKubernetesClusterNetworkProfileDiff(diff *schema.ResourceDiff) error {
+	if v, exists := diff.GetOk("network_profile"); exists {
+		rawProfiles := v.([]interface{})
+		if len(rawProfiles) == 0 {
+			return nil
+		}
+
+		// then ensure the conditionally-required fields are set
+		profile := rawProfiles[0].(map[string]interface{})
+		networkPlugin := profile["network_plugin"].(string)
+
+		if networkPlugin != "kubenet" && networkPlugin != "azure" {
+			return nil
+		}
+
+		dockerBridgeCidr := profile["docker_bridge_cidr"].(string)
+		dnsServiceIP := profile["dns_service_ip"].(string)
+		serviceCidr := profile["service_cidr"].(string)
+
+		// All empty values.
+		if dockerBridgeCidr == "" && dnsServiceIP == "" && serviceCidr == "" {
+			return nil
+		}
+
+		// All set values.
+		if dockerBridgeCidr != "" && dnsServiceIP != "" && serviceCidr != "" {
+			return nil
+		}
+
+		return fmt.Errorf("`docker_bridge_cidr`, `dns_service_ip` and `service_cidr` should all be empty or all should be set.")
+	}
+
+	return nil
+}
+
+func validateKubernetesClusterVersionUpgradeDiff(diff *schema.ResourceDiff, v interface{}) error {
+	// the version can only be validated against the Upgrade Profile of an existing cluster
+	if diff.Id() == "" || !diff.HasChange("kubernetes_version") || !diff.NewValueKnown("kubernetes_version") {
+		return nil
+	}
+
+	old, new := diff.GetChange("kubernetes_version")
+	currentVersion := old.(string)
+	targetVersion := new.(string)
+	if currentVersion == "" || targetVersion == "" {
+		return nil
+	}
+
+	if err := kubernetes.ValidateVersionUpgrade(currentVersion, targetVersion); err != nil {
+		return fmt.Errorf("Error validating `kubernetes_version`: %+v", err)
+	}
+
+	client := v.(*ArmClient).kubernetesClustersClient
+	ctx := v.(*ArmClient).StopContext
+
+	name := diff.Get("name").(string)
+	resGroup := diff.Get("resource_group_name").(string)
+
+	profile, err := client.GetUpgradeProfile(ctx, resGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(profile.Response) {
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Upgrade Profile for Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	upgrades := make([]string, 0)
+	if props := profile.ManagedClusterUpgradeProfileProperties; props != nil && props.ControlPlaneProfile != nil {
+		// when a previous upgrade failed part-way through the Control Plane is already running the target version,
+		// in which case the remaining Agent Pools are upgraded
+		if v := props.ControlPlaneProfile.KubernetesVersion; v != nil && *v == targetVersion {
+			return nil
+		}
+
+		if props.ControlPlaneProfile.Upgrades != nil {
+			upgrades = *props.ControlPlaneProfile.Upgrades
+		}
+	}
+
+	for _, upgrade := range upgrades {
+		if upgrade == targetVersion {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Kubernetes Version %q is not an available upgrade from %q for Managed Kubernetes Cluster %q (Resource Group %q) - available upgrades are: %s", targetVersion, currentVersion, name, resGroup, strings.Join(upgrades, ", "))
+}
+
 func resourceArmKubernetesClusterCreateUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).kubernetesClustersClient
 	ctx := meta.(*ArmClient).StopContext
@@ -565,6 +690,44 @@ func resourceArmKubernetesClusterCreateUpdate(d *schema.ResourceData, meta inter
 	rbacRaw := d.Get("role_based_access_control").([]interface{})
 	rbacEnabled, azureADProfile := expandKubernetesClusterRoleBasedAccessControl(rbacRaw, tenantId)
 
+	azureRMLockByName(name, kubernetesClusterResourceName)
+	defer azureRMUnlockByName(name, kubernetesClusterResourceName)
+
+	apiServerAuthorizedIPRanges := utils.ExpandStringArray(d.Get("api_server_authorized_ip_ranges").(*schema.Set).List())
+
+	if !d.IsNewResource() {
+		// the Service Principal can't be changed via the Managed Cluster API, so the Client Secret is rotated in-place
+		if d.HasChange("service_principal") {
+			log.Printf("[DEBUG] Resetting the Service Principal for Managed Kubernetes Cluster %q (Resource Group %q)..", name, resGroup)
+			future, err := client.ResetServicePrincipalProfile(ctx, resGroup, name, *servicePrincipalProfile)
+			if err != nil {
+				return fmt.Errorf("Error resetting the Service Principal for Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resGroup, err)
+			}
+
+			if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("Error waiting for the Service Principal for Managed Kubernetes Cluster %q (Resource Group %q) to be reset: %+v", name, resGroup, err)
+			}
+		}
+
+		if d.HasChange("kubernetes_version") {
+			old, _ := d.GetChange("kubernetes_version")
+			if err := upgradeKubernetesClusterVersion(meta, resGroup, name, old.(string), kubernetesVersion, servicePrincipalProfile, azureADProfile); err != nil {
+				return err
+			}
+		}
+
+		// Agent Pools managed by the `azurerm_kubernetes_cluster_node_pool` resource are removed by the API
+		// when they're omitted from the Managed Cluster - so we need to pass them through on update
+		existing, err := client.Get(ctx, resGroup, name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving existing Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resGroup, err)
+		}
+
+		if props := existing.ManagedClusterProperties; props != nil && props.AgentPoolProfiles != nil {
+			agentProfiles = appendKubernetesClusterUnmanagedAgentPoolProfiles(agentProfiles, *props.AgentPoolProfiles)
+		}
+	}
+
 	parameters := containerservice.ManagedCluster{
 		Name:     &name,
 		Location: &location,
@@ -582,6 +745,11 @@ func resourceArmKubernetesClusterCreateUpdate(d *schema.ResourceData, meta inter
 		Tags: expandTags(tags),
 	}
 
+	// an empty list is required to remove the authorized IP ranges from an existing cluster
+	if len(*apiServerAuthorizedIPRanges) > 0 || !d.IsNewResource() {
+		parameters.ManagedClusterProperties.APIServerAuthorizedIPRanges = apiServerAuthorizedIPRanges
+	}
+
 	future, err := client.CreateOrUpdate(ctx, resGroup, name, parameters)
 	if err != nil {
 		return fmt.Errorf("Error creating/updating Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resGroup, err)
@@ -605,6 +773,135 @@ func resourceArmKubernetesClusterCreateUpdate(d *schema.ResourceData, meta inter
 	return resourceArmKubernetesClusterRead(d, meta)
 }
 
+// upgradeKubernetesClusterVersion upgrades the Control Plane of the Managed Kubernetes Cluster to the target version
+// and then each of the Agent Pools in turn, so that a failure can be attributed to a specific Agent Pool
+func upgradeKubernetesClusterVersion(meta interface{}, resGroup, name, currentVersion, targetVersion string, servicePrincipalProfile *containerservice.ManagedClusterServicePrincipalProfile, azureADProfile *containerservice.ManagedClusterAADProfile) error {
+	client := meta.(*ArmClient).kubernetesClustersClient
+	poolsClient := meta.(*ArmClient).kubernetesNodePoolsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	cluster, err := client.Get(ctx, resGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Managed Kubernetes Cluster %q (Resource Group %q) prior to upgrading: %+v", name, resGroup, err)
+	}
+
+	props := cluster.ManagedClusterProperties
+	if props == nil || props.AgentPoolProfiles == nil {
+		return fmt.Errorf("Error upgrading Managed Kubernetes Cluster %q (Resource Group %q): `properties` was nil", name, resGroup)
+	}
+
+	// Agent Pools backed by Availability Sets can't be upgraded independently of the Control Plane,
+	// so these are upgraded as a whole by the Managed Cluster API
+	poolNames := make([]string, 0)
+	for _, profile := range *props.AgentPoolProfiles {
+		if profile.Type != containerservice.VirtualMachineScaleSets {
+			log.Printf("[DEBUG] Managed Kubernetes Cluster %q (Resource Group %q) uses %q Agent Pools - upgrading the Control Plane and Agent Pools together", name, resGroup, string(profile.Type))
+			return nil
+		}
+
+		if profile.Name != nil {
+			poolNames = append(poolNames, *profile.Name)
+		}
+	}
+
+	if props.KubernetesVersion != nil && *props.KubernetesVersion == targetVersion {
+		log.Printf("[INFO] The Control Plane of Managed Kubernetes Cluster %q (Resource Group %q) is already running %q - resuming the upgrade of the Agent Pools..", name, resGroup, targetVersion)
+	} else {
+		// the Service Principal & AAD secrets aren't returned from the API, so need to be sent from the config
+		props.ServicePrincipalProfile = servicePrincipalProfile
+		props.AadProfile = azureADProfile
+		props.KubernetesVersion = utils.String(targetVersion)
+		profiles := make([]containerservice.ManagedClusterAgentPoolProfile, 0)
+		for _, profile := range *props.AgentPoolProfiles {
+			if profile.OrchestratorVersion == nil {
+				profile.OrchestratorVersion = utils.String(currentVersion)
+			}
+			profiles = append(profiles, profile)
+		}
+		props.AgentPoolProfiles = &profiles
+
+		log.Printf("[INFO] Upgrading the Control Plane of Managed Kubernetes Cluster %q (Resource Group %q) from %q to %q..", name, resGroup, currentVersion, targetVersion)
+		future, err := client.CreateOrUpdate(ctx, resGroup, name, cluster)
+		if err != nil {
+			return fmt.Errorf("Error upgrading the Control Plane of Managed Kubernetes Cluster %q (Resource Group %q) to %q: %+v", name, resGroup, targetVersion, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for the Control Plane of Managed Kubernetes Cluster %q (Resource Group %q) to be upgraded to %q: %+v", name, resGroup, targetVersion, err)
+		}
+	}
+
+	upgraded := make([]string, 0)
+	for i, poolName := range poolNames {
+		log.Printf("[INFO] Upgrading Agent Pool %q (%d/%d) of Managed Kubernetes Cluster %q (Resource Group %q) to %q..", poolName, i+1, len(poolNames), name, resGroup, targetVersion)
+
+		if err := upgradeKubernetesClusterAgentPoolVersion(ctx, poolsClient, resGroup, name, poolName, targetVersion); err != nil {
+			return fmt.Errorf("Error upgrading Managed Kubernetes Cluster %q (Resource Group %q) to %q - the Control Plane and Agent Pools %q were upgraded, but Agent Pool %q (%d/%d) failed - applying again will resume the upgrade: %+v", name, resGroup, targetVersion, upgraded, poolName, i+1, len(poolNames), err)
+		}
+
+		upgraded = append(upgraded, poolName)
+		log.Printf("[INFO] Upgraded Agent Pool %q (%d/%d) of Managed Kubernetes Cluster %q (Resource Group %q) to %q", poolName, i+1, len(poolNames), name, resGroup, targetVersion)
+	}
+
+	return nil
+}
+
+// findKubernetesClusterOutdatedAgentPoolVersion returns the oldest version of Kubernetes used by an Agent Pool
+// which is behind the Control Plane - or nil if all of the Agent Pools are up to date
+func findKubernetesClusterOutdatedAgentPoolVersion(props *containerservice.ManagedClusterProperties) *string {
+	if props == nil || props.KubernetesVersion == nil || props.AgentPoolProfiles == nil {
+		return nil
+	}
+
+	var oldest *string
+	for _, profile := range *props.AgentPoolProfiles {
+		// only Agent Pools backed by Virtual Machine Scale Sets are upgraded independently of the Control Plane
+		if profile.Type != containerservice.VirtualMachineScaleSets || profile.OrchestratorVersion == nil {
+			continue
+		}
+
+		compareTo := *props.KubernetesVersion
+		if oldest != nil {
+			compareTo = *oldest
+		}
+
+		result, err := kubernetes.CompareVersions(*profile.OrchestratorVersion, compareTo)
+		if err != nil {
+			log.Printf("[DEBUG] Unable to compare the Kubernetes Version %q of an Agent Pool: %+v", *profile.OrchestratorVersion, err)
+			continue
+		}
+
+		if result < 0 {
+			oldest = profile.OrchestratorVersion
+		}
+	}
+
+	return oldest
+}
+
+func upgradeKubernetesClusterAgentPoolVersion(ctx context.Context, client containerservice.AgentPoolsClient, resGroup, clusterName, name, targetVersion string) error {
+	pool, err := client.Get(ctx, resGroup, clusterName, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Agent Pool: %+v", err)
+	}
+
+	if pool.ManagedClusterAgentPoolProfileProperties == nil {
+		return fmt.Errorf("Error retrieving Agent Pool: `properties` was nil")
+	}
+
+	if v := pool.ManagedClusterAgentPoolProfileProperties.OrchestratorVersion; v != nil && *v == targetVersion {
+		return nil
+	}
+
+	pool.ManagedClusterAgentPoolProfileProperties.OrchestratorVersion = utils.String(targetVersion)
+	future, err := client.CreateOrUpdate(ctx, resGroup, clusterName, name, pool)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, client.Client)
+}
+
 func resourceArmKubernetesClusterRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).kubernetesClustersClient
 	ctx := meta.(*ArmClient).StopContext
@@ -641,15 +938,29 @@ func resourceArmKubernetesClusterRead(d *schema.ResourceData, meta interface{})
 	if props := resp.ManagedClusterProperties; props != nil {
 		d.Set("dns_prefix", props.DNSPrefix)
 		d.Set("fqdn", props.Fqdn)
-		d.Set("kubernetes_version", props.KubernetesVersion)
+
+		// if an upgrade failed part-way through some Agent Pools are still running an older version than the
+		// Control Plane - exposing the oldest version means the upgrade is resumed on the next apply
+		kubernetesVersion := props.KubernetesVersion
+		if v := findKubernetesClusterOutdatedAgentPoolVersion(props); v != nil {
+			log.Printf("[DEBUG] Managed Kubernetes Cluster %q (Resource Group %q) has Agent Pools running Kubernetes %q, which is older than the Control Plane", name, resGroup, *v)
+			kubernetesVersion = v
+		}
+		d.Set("kubernetes_version", kubernetesVersion)
 		d.Set("node_resource_group", props.NodeResourceGroup)
 
+		apiServerAuthorizedIPRanges := utils.FlattenStringArray(props.APIServerAuthorizedIPRanges)
+		if err := d.Set("api_server_authorized_ip_ranges", apiServerAuthorizedIPRanges); err != nil {
+			return fmt.Errorf("Error setting `api_server_authorized_ip_ranges`: %+v", err)
+		}
+
 		addonProfiles := flattenKubernetesClusterAddonProfiles(props.AddonProfiles)
 		if err := d.Set("addon_profile", addonProfiles); err != nil {
 			return fmt.Errorf("Error setting `addon_profile`: %+v", err)
 		}
 
-		agentPoolProfiles := flattenKubernetesClusterAgentPoolProfiles(props.AgentPoolProfiles, resp.Fqdn)
+		agentPoolName := d.Get("agent_pool_profile.0.name").(string)
+		agentPoolProfiles := flattenKubernetesClusterAgentPoolProfiles(filterKubernetesClusterAgentPoolProfiles(props.AgentPoolProfiles, agentPoolName), resp.Fqdn)
 		if err := d.Set("agent_pool_profile", agentPoolProfiles); err != nil {
 			return fmt.Errorf("Error setting `agent_pool_profile`: %+v", err)
 		}
@@ -669,7 +980,7 @@ func resourceArmKubernetesClusterRead(d *schema.ResourceData, meta interface{})
 			return fmt.Errorf("Error setting `role_based_access_control`: %+v", err)
 		}
 
-		servicePrincipal := flattenAzureRmKubernetesClusterServicePrincipalProfile(props.ServicePrincipalProfile)
+		servicePrincipal := flattenAzureRmKubernetesClusterServicePrincipalProfile(props.ServicePrincipalProfile, d)
 		if err := d.Set("service_principal", servicePrincipal); err != nil {
 			return fmt.Errorf("Error setting `service_principal`: %+v", err)
 		}
@@ -698,6 +1009,15 @@ func resourceArmKubernetesClusterRead(d *schema.ResourceData, meta interface{})
 		return fmt.Errorf("Error setting `kube_config`: %+v", err)
 	}
 
+	kubeConfigExecRaw, kubeConfigExec, err := flattenKubernetesClusterExecAccessProfile(profile, meta.(*ArmClient))
+	if err != nil {
+		return fmt.Errorf("Error generating exec-based Kube Config for Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+	d.Set("kube_config_exec_raw", kubeConfigExecRaw)
+	if err := d.Set("kube_config_exec", kubeConfigExec); err != nil {
+		return fmt.Errorf("Error setting `kube_config_exec`: %+v", err)
+	}
+
 	flattenAndSetTags(d, resp.Tags)
 
 	return nil
@@ -882,14 +1202,15 @@ func expandKubernetesClusterAgentPoolProfiles(d *schema.ResourceData) []containe
 	vmSize := config["vm_size"].(string)
 	osDiskSizeGB := int32(config["os_disk_size_gb"].(int))
 	osType := config["os_type"].(string)
+	poolType := config["type"].(string)
 
 	profile := containerservice.ManagedClusterAgentPoolProfile{
-		Name:           utils.String(name),
-		Count:          utils.Int32(count),
-		VMSize:         containerservice.VMSizeTypes(vmSize),
-		OsDiskSizeGB:   utils.Int32(osDiskSizeGB),
-		StorageProfile: containerservice.ManagedDisks,
-		OsType:         containerservice.OSType(osType),
+		Name:         utils.String(name),
+		Count:        utils.Int32(count),
+		VMSize:       containerservice.VMSizeTypes(vmSize),
+		OsDiskSizeGB: utils.Int32(osDiskSizeGB),
+		OsType:       containerservice.OSType(osType),
+		Type:         containerservice.AgentPoolType(poolType),
 	}
 
 	if maxPods := int32(config["max_pods"].(int)); maxPods > 0 {
@@ -904,6 +1225,46 @@ func expandKubernetesClusterAgentPoolProfiles(d *schema.ResourceData) []containe
 	return []containerservice.ManagedClusterAgentPoolProfile{profile}
 }
 
+// appendKubernetesClusterUnmanagedAgentPoolProfiles appends the existing Agent Pools which aren't defined
+// in the `agent_pool_profile` block (e.g. those managed by `azurerm_kubernetes_cluster_node_pool`)
+func appendKubernetesClusterUnmanagedAgentPoolProfiles(profiles []containerservice.ManagedClusterAgentPoolProfile, existing []containerservice.ManagedClusterAgentPoolProfile) []containerservice.ManagedClusterAgentPoolProfile {
+	for _, existingProfile := range existing {
+		if existingProfile.Name == nil {
+			continue
+		}
+
+		managed := false
+		for _, profile := range profiles {
+			if profile.Name != nil && strings.EqualFold(*profile.Name, *existingProfile.Name) {
+				managed = true
+				break
+			}
+		}
+
+		if !managed {
+			profiles = append(profiles, existingProfile)
+		}
+	}
+
+	return profiles
+}
+
+// filterKubernetesClusterAgentPoolProfiles returns only the Agent Pool managed by the `agent_pool_profile` block,
+// falling back to the first Agent Pool when the name isn't known (e.g. during import)
+func filterKubernetesClusterAgentPoolProfiles(input *[]containerservice.ManagedClusterAgentPoolProfile, name string) *[]containerservice.ManagedClusterAgentPoolProfile {
+	if input == nil || len(*input) == 0 {
+		return input
+	}
+
+	for _, profile := range *input {
+		if profile.Name != nil && strings.EqualFold(*profile.Name, name) {
+			return &[]containerservice.ManagedClusterAgentPoolProfile{profile}
+		}
+	}
+
+	return &[]containerservice.ManagedClusterAgentPoolProfile{(*input)[0]}
+}
+
 func flattenKubernetesClusterAgentPoolProfiles(profiles *[]containerservice.ManagedClusterAgentPoolProfile, fqdn *string) []interface{} {
 	if profiles == nil {
 		return []interface{}{}
@@ -947,6 +1308,12 @@ func flattenKubernetesClusterAgentPoolProfiles(profiles *[]containerservice.Mana
 			agentPoolProfile["max_pods"] = int(*profile.MaxPods)
 		}
 
+		// clusters created prior to the introduction of VirtualMachineScaleSets don't return a type
+		agentPoolProfile["type"] = string(containerservice.AvailabilitySet)
+		if profile.Type != "" {
+			agentPoolProfile["type"] = string(profile.Type)
+		}
+
 		agentPoolProfiles = append(agentPoolProfiles, agentPoolProfile)
 	}
 
@@ -1014,7 +1381,7 @@ func flattenKubernetesClusterLinuxProfile(profile *containerservice.LinuxProfile
 	return []interface{}{values}
 }
 
-func expandKubernetesClusterNetworkProfile(d *schema.ResourceData) *containerservice.NetworkProfile {
+func expandKubernetesClusterNetworkProfile(d *schema.ResourceData) *containerservice.NetworkProfileType {
 	configs := d.Get("network_profile").([]interface{})
 	if len(configs) == 0 {
 		return nil
@@ -1026,7 +1393,7 @@ func expandKubernetesClusterNetworkProfile(d *schema.ResourceData) *containerser
 
 	networkPolicy := config["network_policy"].(string)
 
-	networkProfile := containerservice.NetworkProfile{
+	networkProfile := containerservice.NetworkProfileType{
 		NetworkPlugin: containerservice.NetworkPlugin(networkPlugin),
 		NetworkPolicy: containerservice.NetworkPolicy(networkPolicy),
 	}
@@ -1054,7 +1421,7 @@ func expandKubernetesClusterNetworkProfile(d *schema.ResourceData) *containerser
 	return &networkProfile
 }
 
-func flattenKubernetesClusterNetworkProfile(profile *containerservice.NetworkProfile) []interface{} {
+func flattenKubernetesClusterNetworkProfile(profile *containerservice.NetworkProfileType) []interface{} {
 	if profile == nil {
 		return []interface{}{}
 	}
@@ -1190,7 +1557,7 @@ func expandAzureRmKubernetesClusterServicePrincipal(d *schema.ResourceData) *con
 	return &principal
 }
 
-func flattenAzureRmKubernetesClusterServicePrincipalProfile(profile *containerservice.ManagedClusterServicePrincipalProfile) *schema.Set {
+func flattenAzureRmKubernetesClusterServicePrincipalProfile(profile *containerservice.ManagedClusterServicePrincipalProfile, d *schema.ResourceData) *schema.Set {
 	if profile == nil {
 		return nil
 	}
@@ -1206,6 +1573,11 @@ func flattenAzureRmKubernetesClusterServicePrincipalProfile(profile *containerse
 	}
 	if secret := profile.Secret; secret != nil {
 		values["client_secret"] = *secret
+	} else if existing := d.Get("service_principal").(*schema.Set).List(); len(existing) > 0 {
+		// the Client Secret isn't returned by the API, so we pass the existing value through to be able to detect rotation
+		if v, ok := existing[0].(map[string]interface{}); ok {
+			values["client_secret"] = v["client_secret"]
+		}
 	}
 
 	servicePrincipalProfiles.Add(values)
@@ -1260,3 +1632,58 @@ func flattenKubernetesClusterKubeConfigAAD(config kubernetes.KubeConfigAAD) []in
 
 	return []interface{}{values}
 }
+
+// flattenKubernetesClusterExecAccessProfile builds a Kube Config which retrieves Azure Active Directory tokens through
+// the `kubelogin` credential plugin, authenticating using the same credentials as the Provider.
+// This is only applicable to clusters integrated with Azure Active Directory.
+func flattenKubernetesClusterExecAccessProfile(profile containerservice.ManagedClusterAccessProfile, client *ArmClient) (*string, []interface{}, error) {
+	if profile.AccessProfile == nil || profile.AccessProfile.KubeConfig == nil {
+		return nil, []interface{}{}, nil
+	}
+
+	rawConfig := string(*profile.AccessProfile.KubeConfig)
+	if !strings.Contains(rawConfig, "apiserver-id:") {
+		return nil, []interface{}{}, nil
+	}
+
+	kubeConfigAAD, err := kubernetes.ParseKubeConfigAAD(rawConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	options, err := kubernetes.NewExecCredentialOptions(kubernetes.ExecCredentialAuthentication{
+		Environment:                 client.environment.Name,
+		ClientID:                    client.clientId,
+		UsingServicePrincipal:       client.usingServicePrincipal,
+		UsingClientCertificate:      client.usingClientCertificate,
+		UsingManagedServiceIdentity: client.usingManagedServiceIdentity,
+	})
+	if err != nil {
+		log.Printf("[DEBUG] Not generating an exec-based KubeConfig: %+v", err)
+		return nil, []interface{}{}, nil
+	}
+
+	kubeConfigExec, err := kubernetes.NewKubeConfigExec(*kubeConfigAAD, *options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kubeConfigExecRaw, err := kubeConfigExec.Raw()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// we don't size-check these since they're validated in the Parse/New methods
+	cluster := kubeConfigExec.Clusters[0].Cluster
+	exec := kubeConfigExec.Users[0].User.Exec
+
+	values := map[string]interface{}{
+		"host":                   cluster.Server,
+		"cluster_ca_certificate": cluster.ClusterAuthorityData,
+		"api_version":            exec.APIVersion,
+		"command":                exec.Command,
+		"args":                   exec.Args,
+	}
+
+	return utils.String(kubeConfigExecRaw), []interface{}{values}, nil
+}
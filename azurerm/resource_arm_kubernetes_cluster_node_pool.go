@@ -0,0 +1,378 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2019-02-01/containerservice"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmKubernetesClusterNodePool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmKubernetesClusterNodePoolCreateUpdate,
+		Read:   resourceArmKubernetesClusterNodePoolRead,
+		Update: resourceArmKubernetesClusterNodePoolCreateUpdate,
+		Delete: resourceArmKubernetesClusterNodePoolDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.KubernetesAgentPoolName,
+			},
+
+			"kubernetes_cluster_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"vm_size": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppress.CaseDifference,
+				ValidateFunc:     validate.NoEmptyStrings,
+			},
+
+			// NOTE: `count` is a reserved field name in Terraform 0.12
+			"node_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+				// the number of nodes is managed by the cluster autoscaler when it's enabled
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Get("enable_auto_scaling").(bool)
+				},
+			},
+
+			"enable_auto_scaling": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"min_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+
+			"max_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+
+			"availability_zones": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						"1",
+						"2",
+						"3",
+					}, false),
+				},
+			},
+
+			"os_disk_size_gb": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"os_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(containerservice.Linux),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(containerservice.Linux),
+					string(containerservice.Windows),
+				}, true),
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+
+			"vnet_subnet_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"max_pods": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			// the Kubernetes Version is upgraded alongside the Control Plane by `azurerm_kubernetes_cluster`
+			"orchestrator_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmKubernetesClusterNodePoolCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	clustersClient := meta.(*ArmClient).kubernetesClustersClient
+	client := meta.(*ArmClient).kubernetesNodePoolsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	log.Printf("[INFO] preparing arguments for Kubernetes Cluster Node Pool create/update.")
+
+	name := d.Get("name").(string)
+	clusterName := d.Get("kubernetes_cluster_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, clusterName, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_kubernetes_cluster_node_pool", *existing.ID)
+		}
+	}
+
+	if d.IsNewResource() {
+		cluster, err := clustersClient.Get(ctx, resourceGroup, clusterName)
+		if err != nil {
+			if utils.ResponseWasNotFound(cluster.Response) {
+				return fmt.Errorf("Kubernetes Cluster %q (Resource Group %q) was not found", clusterName, resourceGroup)
+			}
+
+			return fmt.Errorf("Error retrieving Kubernetes Cluster %q (Resource Group %q): %+v", clusterName, resourceGroup, err)
+		}
+
+		// the Agent Pools API is only available for clusters backed by Virtual Machine Scale Sets
+		if props := cluster.ManagedClusterProperties; props != nil && props.AgentPoolProfiles != nil {
+			for _, profile := range *props.AgentPoolProfiles {
+				if profile.Type != containerservice.VirtualMachineScaleSets {
+					return fmt.Errorf("Node Pools can only be added to Kubernetes Clusters where the `type` of the `agent_pool_profile` is `VirtualMachineScaleSets` - Kubernetes Cluster %q (Resource Group %q) uses %q", clusterName, resourceGroup, string(profile.Type))
+				}
+			}
+		}
+	}
+
+	enableAutoScaling := d.Get("enable_auto_scaling").(bool)
+	minCount := d.Get("min_count").(int)
+	maxCount := d.Get("max_count").(int)
+	count := d.Get("node_count").(int)
+
+	profile := containerservice.ManagedClusterAgentPoolProfileProperties{
+		VMSize:            containerservice.VMSizeTypes(d.Get("vm_size").(string)),
+		OsType:            containerservice.OSType(d.Get("os_type").(string)),
+		Type:              containerservice.VirtualMachineScaleSets,
+		EnableAutoScaling: utils.Bool(enableAutoScaling),
+	}
+
+	if enableAutoScaling {
+		if minCount == 0 || maxCount == 0 {
+			return fmt.Errorf("`min_count` and `max_count` must be specified when `enable_auto_scaling` is set to `true`")
+		}
+
+		if minCount > maxCount {
+			return fmt.Errorf("`min_count` (%d) must be less than or equal to `max_count` (%d)", minCount, maxCount)
+		}
+
+		// the current number of nodes is managed by the autoscaler, but must be within the new bounds
+		if count < minCount {
+			count = minCount
+		}
+		if count > maxCount {
+			count = maxCount
+		}
+
+		profile.MinCount = utils.Int32(int32(minCount))
+		profile.MaxCount = utils.Int32(int32(maxCount))
+	} else {
+		if minCount > 0 || maxCount > 0 {
+			return fmt.Errorf("`min_count` and `max_count` can only be specified when `enable_auto_scaling` is set to `true`")
+		}
+
+		if count == 0 {
+			count = 1
+		}
+	}
+
+	profile.Count = utils.Int32(int32(count))
+
+	if v := d.Get("availability_zones").([]interface{}); len(v) > 0 {
+		profile.AvailabilityZones = utils.ExpandStringArray(v)
+	}
+
+	if osDiskSizeGB := d.Get("os_disk_size_gb").(int); osDiskSizeGB > 0 {
+		profile.OsDiskSizeGB = utils.Int32(int32(osDiskSizeGB))
+	}
+
+	if vnetSubnetID := d.Get("vnet_subnet_id").(string); vnetSubnetID != "" {
+		profile.VnetSubnetID = utils.String(vnetSubnetID)
+	}
+
+	if maxPods := d.Get("max_pods").(int); maxPods > 0 {
+		profile.MaxPods = utils.Int32(int32(maxPods))
+	}
+
+	parameters := containerservice.AgentPool{
+		Name:                                     utils.String(name),
+		ManagedClusterAgentPoolProfileProperties: &profile,
+	}
+
+	// the Kubernetes Cluster can only process a single change at a time
+	azureRMLockByName(clusterName, kubernetesClusterResourceName)
+	defer azureRMUnlockByName(clusterName, kubernetesClusterResourceName)
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, clusterName, name, parameters)
+	if err != nil {
+		return fmt.Errorf("Error creating/updating Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for completion of Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, clusterName, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read ID for Node Pool %q (Kubernetes Cluster %q / Resource Group %q)", name, clusterName, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmKubernetesClusterNodePoolRead(d, meta)
+}
+
+func resourceArmKubernetesClusterNodePoolRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).kubernetesNodePoolsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	clusterName := id.Path["managedClusters"]
+	name := id.Path["agentPools"]
+
+	resp, err := client.Get(ctx, resourceGroup, clusterName, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Node Pool %q was not found in Kubernetes Cluster %q / Resource Group %q - removing from state!", name, clusterName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("kubernetes_cluster_name", clusterName)
+	d.Set("resource_group_name", resourceGroup)
+
+	if props := resp.ManagedClusterAgentPoolProfileProperties; props != nil {
+		d.Set("vm_size", string(props.VMSize))
+		d.Set("os_type", string(props.OsType))
+		d.Set("vnet_subnet_id", props.VnetSubnetID)
+
+		if props.Count != nil {
+			d.Set("node_count", int(*props.Count))
+		}
+
+		enableAutoScaling := false
+		if props.EnableAutoScaling != nil {
+			enableAutoScaling = *props.EnableAutoScaling
+		}
+		d.Set("enable_auto_scaling", enableAutoScaling)
+
+		minCount := 0
+		if props.MinCount != nil {
+			minCount = int(*props.MinCount)
+		}
+		d.Set("min_count", minCount)
+
+		maxCount := 0
+		if props.MaxCount != nil {
+			maxCount = int(*props.MaxCount)
+		}
+		d.Set("max_count", maxCount)
+
+		if err := d.Set("availability_zones", utils.FlattenStringArray(props.AvailabilityZones)); err != nil {
+			return fmt.Errorf("Error setting `availability_zones`: %+v", err)
+		}
+
+		if props.OsDiskSizeGB != nil {
+			d.Set("os_disk_size_gb", int(*props.OsDiskSizeGB))
+		}
+
+		if props.MaxPods != nil {
+			d.Set("max_pods", int(*props.MaxPods))
+		}
+
+		d.Set("orchestrator_version", props.OrchestratorVersion)
+	}
+
+	return nil
+}
+
+func resourceArmKubernetesClusterNodePoolDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).kubernetesNodePoolsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	clusterName := id.Path["managedClusters"]
+	name := id.Path["agentPools"]
+
+	azureRMLockByName(clusterName, kubernetesClusterResourceName)
+	defer azureRMUnlockByName(clusterName, kubernetesClusterResourceName)
+
+	future, err := client.Delete(ctx, resourceGroup, clusterName, name)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for the deletion of Node Pool %q (Kubernetes Cluster %q / Resource Group %q): %+v", name, clusterName, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,358 @@
+package azurerm
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAccAzureRMKubernetesClusterNodePool_basic(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster_node_pool.test"
+	ri := tf.AccRandTimeInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterNodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesClusterNodePool_manualScale(ri, clientId, clientSecret, testLocation(), 1),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "node_count", "1"),
+					resource.TestCheckResourceAttr(resourceName, "enable_auto_scaling", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "max_pods"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesClusterNodePool_requiresImport(t *testing.T) {
+	if !requireResourcesToBeImported {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azurerm_kubernetes_cluster_node_pool.test"
+	ri := tf.AccRandTimeInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterNodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesClusterNodePool_manualScale(ri, clientId, clientSecret, location, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccAzureRMKubernetesClusterNodePool_requiresImport(ri, clientId, clientSecret, location),
+				ExpectError: testRequiresImportError("azurerm_kubernetes_cluster_node_pool"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesClusterNodePool_manualScaleUpdate(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster_node_pool.test"
+	ri := tf.AccRandTimeInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterNodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesClusterNodePool_manualScale(ri, clientId, clientSecret, location, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "node_count", "1"),
+				),
+			},
+			{
+				Config: testAccAzureRMKubernetesClusterNodePool_manualScale(ri, clientId, clientSecret, location, 3),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "node_count", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesClusterNodePool_autoScale(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster_node_pool.test"
+	ri := tf.AccRandTimeInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterNodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesClusterNodePool_autoScale(ri, clientId, clientSecret, location, 1, 3),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "enable_auto_scaling", "true"),
+					resource.TestCheckResourceAttr(resourceName, "min_count", "1"),
+					resource.TestCheckResourceAttr(resourceName, "max_count", "3"),
+				),
+			},
+			{
+				Config: testAccAzureRMKubernetesClusterNodePool_autoScale(ri, clientId, clientSecret, location, 2, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "min_count", "2"),
+					resource.TestCheckResourceAttr(resourceName, "max_count", "5"),
+				),
+			},
+			{
+				Config: testAccAzureRMKubernetesClusterNodePool_manualScale(ri, clientId, clientSecret, location, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "enable_auto_scaling", "false"),
+					resource.TestCheckResourceAttr(resourceName, "min_count", "0"),
+					resource.TestCheckResourceAttr(resourceName, "max_count", "0"),
+					resource.TestCheckResourceAttr(resourceName, "node_count", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesClusterNodePool_multiplePools(t *testing.T) {
+	ri := tf.AccRandTimeInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterNodePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesClusterNodePool_multiplePools(ri, clientId, clientSecret, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists("azurerm_kubernetes_cluster_node_pool.first"),
+					testCheckAzureRMKubernetesClusterNodePoolExists("azurerm_kubernetes_cluster_node_pool.second"),
+					resource.TestCheckResourceAttr("azurerm_kubernetes_cluster_node_pool.second", "availability_zones.#", "2"),
+					resource.TestCheckResourceAttr("azurerm_kubernetes_cluster.test", "agent_pool_profile.#", "1"),
+				),
+			},
+			{
+				// removing a Node Pool shouldn't affect the Cluster or the remaining Node Pool
+				Config: testAccAzureRMKubernetesClusterNodePool_manualScale(ri, clientId, clientSecret, location, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterNodePoolExists("azurerm_kubernetes_cluster_node_pool.test"),
+					resource.TestCheckResourceAttr("azurerm_kubernetes_cluster.test", "agent_pool_profile.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMKubernetesClusterNodePoolExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		clusterName := rs.Primary.Attributes["kubernetes_cluster_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).kubernetesNodePoolsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, clusterName, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Node Pool %q (Kubernetes Cluster %q / Resource Group %q) does not exist", name, clusterName, resourceGroup)
+			}
+
+			return fmt.Errorf("Bad: Get on kubernetesNodePoolsClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMKubernetesClusterNodePoolOrchestratorVersion(resourceName string, version string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		clusterName := rs.Primary.Attributes["kubernetes_cluster_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).kubernetesNodePoolsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, clusterName, name)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on kubernetesNodePoolsClient: %+v", err)
+		}
+
+		if props := resp.ManagedClusterAgentPoolProfileProperties; props == nil || props.OrchestratorVersion == nil || *props.OrchestratorVersion != version {
+			return fmt.Errorf("Bad: expected Node Pool %q (Kubernetes Cluster %q / Resource Group %q) to be running Kubernetes %q", name, clusterName, resourceGroup, version)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMKubernetesClusterNodePoolDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).kubernetesNodePoolsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_kubernetes_cluster_node_pool" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		clusterName := rs.Primary.Attributes["kubernetes_cluster_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := client.Get(ctx, resourceGroup, clusterName, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return nil
+			}
+
+			return err
+		}
+
+		return fmt.Errorf("Node Pool still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccAzureRMKubernetesClusterNodePool_template(rInt int, clientId, clientSecret, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  dns_prefix          = "acctestaks%d"
+
+  agent_pool_profile {
+    name    = "default"
+    count   = "1"
+    vm_size = "Standard_DS2_v2"
+    type    = "VirtualMachineScaleSets"
+  }
+
+  service_principal {
+    client_id     = "%s"
+    client_secret = "%s"
+  }
+}
+`, rInt, location, rInt, rInt, clientId, clientSecret)
+}
+
+func testAccAzureRMKubernetesClusterNodePool_manualScale(rInt int, clientId, clientSecret, location string, count int) string {
+	template := testAccAzureRMKubernetesClusterNodePool_template(rInt, clientId, clientSecret, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_node_pool" "test" {
+  name                    = "internal"
+  kubernetes_cluster_name = "${azurerm_kubernetes_cluster.test.name}"
+  resource_group_name     = "${azurerm_kubernetes_cluster.test.resource_group_name}"
+  vm_size                 = "Standard_DS2_v2"
+  node_count              = %d
+}
+`, template, count)
+}
+
+func testAccAzureRMKubernetesClusterNodePool_requiresImport(rInt int, clientId, clientSecret, location string) string {
+	template := testAccAzureRMKubernetesClusterNodePool_manualScale(rInt, clientId, clientSecret, location, 1)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_node_pool" "import" {
+  name                    = "${azurerm_kubernetes_cluster_node_pool.test.name}"
+  kubernetes_cluster_name = "${azurerm_kubernetes_cluster_node_pool.test.kubernetes_cluster_name}"
+  resource_group_name     = "${azurerm_kubernetes_cluster_node_pool.test.resource_group_name}"
+  vm_size                 = "${azurerm_kubernetes_cluster_node_pool.test.vm_size}"
+  node_count              = "${azurerm_kubernetes_cluster_node_pool.test.node_count}"
+}
+`, template)
+}
+
+func testAccAzureRMKubernetesClusterNodePool_autoScale(rInt int, clientId, clientSecret, location string, minCount, maxCount int) string {
+	template := testAccAzureRMKubernetesClusterNodePool_template(rInt, clientId, clientSecret, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_node_pool" "test" {
+  name                    = "internal"
+  kubernetes_cluster_name = "${azurerm_kubernetes_cluster.test.name}"
+  resource_group_name     = "${azurerm_kubernetes_cluster.test.resource_group_name}"
+  vm_size                 = "Standard_DS2_v2"
+  enable_auto_scaling     = true
+  min_count               = %d
+  max_count               = %d
+}
+`, template, minCount, maxCount)
+}
+
+func testAccAzureRMKubernetesClusterNodePool_multiplePools(rInt int, clientId, clientSecret, location string) string {
+	template := testAccAzureRMKubernetesClusterNodePool_manualScale(rInt, clientId, clientSecret, location, 1)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_node_pool" "first" {
+  name                    = "first"
+  kubernetes_cluster_name = "${azurerm_kubernetes_cluster.test.name}"
+  resource_group_name     = "${azurerm_kubernetes_cluster.test.resource_group_name}"
+  vm_size                 = "Standard_DS2_v2"
+  node_count              = 1
+}
+
+resource "azurerm_kubernetes_cluster_node_pool" "second" {
+  name                    = "second"
+  kubernetes_cluster_name = "${azurerm_kubernetes_cluster.test.name}"
+  resource_group_name     = "${azurerm_kubernetes_cluster.test.resource_group_name}"
+  vm_size                 = "Standard_DS3_v2"
+  node_count              = 2
+  availability_zones      = ["1", "2"]
+}
+`, template)
+}
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"regexp"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2019-02-01/containerservice"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
 func TestAccAzureRMKubernetesCluster_basic(t *testing.T) {
@@ -38,6 +41,7 @@ func TestAccAzureRMKubernetesCluster_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "kube_config.0.password"),
 					resource.TestCheckResourceAttr(resourceName, "kube_admin_config.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "kube_admin_config_raw", ""),
+					resource.TestCheckResourceAttr(resourceName, "kube_config_exec.#", "0"),
 					resource.TestCheckResourceAttrSet(resourceName, "agent_pool_profile.0.max_pods"),
 				),
 			},
@@ -139,6 +143,9 @@ func TestAccAzureRMKubernetesCluster_roleBasedAccessControlAAD(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "role_based_access_control.0.azure_active_directory.0.tenant_id"),
 					resource.TestCheckResourceAttr(resourceName, "kube_admin_config.#", "1"),
 					resource.TestCheckResourceAttrSet(resourceName, "kube_admin_config_raw"),
+					resource.TestCheckResourceAttr(resourceName, "kube_config_exec.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "kube_config_exec.0.command", "kubelogin"),
+					resource.TestCheckResourceAttrSet(resourceName, "kube_config_exec_raw"),
 				),
 			},
 			{
@@ -259,6 +266,145 @@ func TestAccAzureRMKubernetesCluster_upgradeConfig(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMKubernetesCluster_upgradeNodePools(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster.test"
+	nodePoolResourceName := "azurerm_kubernetes_cluster_node_pool.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesCluster_upgradeNodePools(ri, location, clientId, clientSecret, "1.12.7"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "kubernetes_version", "1.12.7"),
+					resource.TestCheckResourceAttr(nodePoolResourceName, "orchestrator_version", "1.12.7"),
+				),
+			},
+			{
+				Config: testAccAzureRMKubernetesCluster_upgradeNodePools(ri, location, clientId, clientSecret, "1.13.5"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "kubernetes_version", "1.13.5"),
+					// the Node Pool is upgraded by the Cluster, so isn't refreshed in state until the next plan
+					testCheckAzureRMKubernetesClusterNodePoolOrchestratorVersion(nodePoolResourceName, "1.13.5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesCluster_upgradeNodePoolsResume(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster.test"
+	nodePoolResourceName := "azurerm_kubernetes_cluster_node_pool.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	resourceGroup := fmt.Sprintf("acctestRG-%d", ri)
+	clusterName := fmt.Sprintf("acctestaks%d", ri)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesCluster_upgradeNodePools(ri, location, clientId, clientSecret, "1.12.7"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "kubernetes_version", "1.12.7"),
+				),
+			},
+			{
+				// simulates an upgrade which failed after the Control Plane was upgraded, leaving the Agent Pools on the old version
+				PreConfig: testUpgradeAzureRMKubernetesClusterControlPlane(t, resourceGroup, clusterName, clientId, clientSecret, "1.13.5"),
+				Config:    testAccAzureRMKubernetesCluster_upgradeNodePools(ri, location, clientId, clientSecret, "1.13.5"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "kubernetes_version", "1.13.5"),
+					testCheckAzureRMKubernetesClusterNodePoolOrchestratorVersion(nodePoolResourceName, "1.13.5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesCluster_upgradeSkippingMinorVersion(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesCluster_upgrade(ri, location, clientId, clientSecret, "1.11.9"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "kubernetes_version", "1.11.9"),
+				),
+			},
+			{
+				Config:      testAccAzureRMKubernetesCluster_upgrade(ri, location, clientId, clientSecret, "1.13.5"),
+				ExpectError: regexp.MustCompile("minor versions can't be skipped"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesCluster_apiServerAuthorizedIPRanges(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesCluster_apiServerAuthorizedIPRanges(ri, clientId, clientSecret, location, `"8.8.8.8/32", "8.8.4.4/32"`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "api_server_authorized_ip_ranges.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccAzureRMKubernetesCluster_apiServerAuthorizedIPRanges(ri, clientId, clientSecret, location, `"1.1.1.0/24"`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "api_server_authorized_ip_ranges.#", "1"),
+				),
+			},
+			{
+				Config: testAccAzureRMKubernetesCluster_basic(ri, clientId, clientSecret, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "api_server_authorized_ip_ranges.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAzureRMKubernetesCluster_internalNetwork(t *testing.T) {
 	resourceName := "azurerm_kubernetes_cluster.test"
 	ri := tf.AccRandTimeInt()
@@ -500,6 +646,127 @@ func TestAccAzureRMKubernetesCluster_advancedNetworkingAzureCalicoPolicyComplete
 	})
 }
 
+func TestAzureRMKubernetesCluster_unmanagedAgentPoolProfiles(t *testing.T) {
+	profiles := []containerservice.ManagedClusterAgentPoolProfile{
+		{Name: utils.String("default"), Count: utils.Int32(2)},
+	}
+	existing := []containerservice.ManagedClusterAgentPoolProfile{
+		{Name: utils.String("default"), Count: utils.Int32(1)},
+		{Name: utils.String("internal"), Count: utils.Int32(3)},
+	}
+
+	actual := appendKubernetesClusterUnmanagedAgentPoolProfiles(profiles, existing)
+	if len(actual) != 2 {
+		t.Fatalf("Expected 2 Agent Pools but got %d", len(actual))
+	}
+	if *actual[0].Name != "default" || *actual[0].Count != 2 {
+		t.Fatalf("Expected the configured `default` Agent Pool to take precedence but got %q with %d nodes", *actual[0].Name, *actual[0].Count)
+	}
+	if *actual[1].Name != "internal" {
+		t.Fatalf("Expected the unmanaged `internal` Agent Pool to be retained but got %q", *actual[1].Name)
+	}
+
+	filtered := filterKubernetesClusterAgentPoolProfiles(&existing, "internal")
+	if len(*filtered) != 1 || *(*filtered)[0].Name != "internal" {
+		t.Fatalf("Expected only the `internal` Agent Pool to be returned but got %+v", *filtered)
+	}
+
+	filtered = filterKubernetesClusterAgentPoolProfiles(&existing, "")
+	if len(*filtered) != 1 || *(*filtered)[0].Name != "default" {
+		t.Fatalf("Expected the first Agent Pool to be returned when the name is unknown but got %+v", *filtered)
+	}
+}
+
+func TestAzureRMKubernetesCluster_outdatedAgentPoolVersion(t *testing.T) {
+	vmss := containerservice.VirtualMachineScaleSets
+	cases := []struct {
+		Name     string
+		Profiles []containerservice.ManagedClusterAgentPoolProfile
+		Expected *string
+	}{
+		{
+			Name: "Up To Date",
+			Profiles: []containerservice.ManagedClusterAgentPoolProfile{
+				{Name: utils.String("default"), Type: vmss, OrchestratorVersion: utils.String("1.13.5")},
+				{Name: utils.String("internal"), Type: vmss, OrchestratorVersion: utils.String("1.13.5")},
+			},
+		},
+		{
+			Name: "Partially Upgraded",
+			Profiles: []containerservice.ManagedClusterAgentPoolProfile{
+				{Name: utils.String("default"), Type: vmss, OrchestratorVersion: utils.String("1.13.5")},
+				{Name: utils.String("internal"), Type: vmss, OrchestratorVersion: utils.String("1.12.7")},
+				{Name: utils.String("other"), Type: vmss, OrchestratorVersion: utils.String("1.12.8")},
+			},
+			Expected: utils.String("1.12.7"),
+		},
+		{
+			Name: "Availability Sets",
+			Profiles: []containerservice.ManagedClusterAgentPoolProfile{
+				{Name: utils.String("default"), Type: containerservice.AvailabilitySet, OrchestratorVersion: utils.String("1.12.7")},
+			},
+		},
+		{
+			Name: "No Orchestrator Version",
+			Profiles: []containerservice.ManagedClusterAgentPoolProfile{
+				{Name: utils.String("default"), Type: vmss},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			props := containerservice.ManagedClusterProperties{
+				KubernetesVersion: utils.String("1.13.5"),
+				AgentPoolProfiles: &tc.Profiles,
+			}
+
+			actual := findKubernetesClusterOutdatedAgentPoolVersion(&props)
+			if (actual == nil) != (tc.Expected == nil) || (actual != nil && *actual != *tc.Expected) {
+				t.Fatalf("Expected %v but got %v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+// testUpgradeAzureRMKubernetesClusterControlPlane upgrades only the Control Plane of the Managed Kubernetes Cluster,
+// pinning the Agent Pools to their current version
+func testUpgradeAzureRMKubernetesClusterControlPlane(t *testing.T, resourceGroup, name, clientId, clientSecret, version string) func() {
+	return func() {
+		client := testAccProvider.Meta().(*ArmClient).kubernetesClustersClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		cluster, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			t.Fatalf("Bad: Get on kubernetesClustersClient: %+v", err)
+		}
+
+		props := cluster.ManagedClusterProperties
+		profiles := make([]containerservice.ManagedClusterAgentPoolProfile, 0)
+		for _, profile := range *props.AgentPoolProfiles {
+			if profile.OrchestratorVersion == nil {
+				profile.OrchestratorVersion = props.KubernetesVersion
+			}
+			profiles = append(profiles, profile)
+		}
+		props.AgentPoolProfiles = &profiles
+		props.KubernetesVersion = utils.String(version)
+		props.ServicePrincipalProfile = &containerservice.ManagedClusterServicePrincipalProfile{
+			ClientID: utils.String(clientId),
+			Secret:   utils.String(clientSecret),
+		}
+
+		future, err := client.CreateOrUpdate(ctx, resourceGroup, name, cluster)
+		if err != nil {
+			t.Fatalf("Bad: upgrading the Control Plane of Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			t.Fatalf("Bad: waiting for the Control Plane of Managed Kubernetes Cluster %q (Resource Group %q) to be upgraded: %+v", name, resourceGroup, err)
+		}
+	}
+}
+
 func testCheckAzureRMKubernetesClusterExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// Ensure we have enough information in state to look up in API
@@ -583,6 +850,34 @@ resource "azurerm_kubernetes_cluster" "test" {
 `, rInt, location, rInt, rInt, clientId, clientSecret)
 }
 
+func testAccAzureRMKubernetesCluster_apiServerAuthorizedIPRanges(rInt int, clientId string, clientSecret string, location string, ipRanges string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                            = "acctestaks%d"
+  location                        = "${azurerm_resource_group.test.location}"
+  resource_group_name             = "${azurerm_resource_group.test.name}"
+  dns_prefix                      = "acctestaks%d"
+  api_server_authorized_ip_ranges = [%s]
+
+  agent_pool_profile {
+    name    = "default"
+    count   = "1"
+    vm_size = "Standard_DS2_v2"
+  }
+
+  service_principal {
+    client_id     = "%s"
+    client_secret = "%s"
+  }
+}
+`, rInt, location, rInt, rInt, ipRanges, clientId, clientSecret)
+}
+
 func testAccAzureRMKubernetesCluster_requiresImport(rInt int, clientId, clientSecret, location string) string {
 	template := testAccAzureRMKubernetesCluster_basic(rInt, clientId, clientSecret, location)
 	return fmt.Sprintf(`
@@ -1033,6 +1328,43 @@ resource "azurerm_kubernetes_cluster" "test" {
 `, rInt, location, rInt, rInt, version, rInt, clientId, clientSecret)
 }
 
+func testAccAzureRMKubernetesCluster_upgradeNodePools(rInt int, location, clientId, clientSecret, version string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  dns_prefix          = "acctestaks%d"
+  kubernetes_version  = "%s"
+
+  agent_pool_profile {
+    name    = "default"
+    count   = "1"
+    vm_size = "Standard_DS2_v2"
+    type    = "VirtualMachineScaleSets"
+  }
+
+  service_principal {
+    client_id     = "%s"
+    client_secret = "%s"
+  }
+}
+
+resource "azurerm_kubernetes_cluster_node_pool" "test" {
+  name                    = "internal"
+  kubernetes_cluster_name = "${azurerm_kubernetes_cluster.test.name}"
+  resource_group_name     = "${azurerm_kubernetes_cluster.test.resource_group_name}"
+  vm_size                 = "Standard_DS2_v2"
+  node_count              = 1
+}
+`, rInt, location, rInt, rInt, version, clientId, clientSecret)
+}
+
 func testAccAzureRMKubernetesCluster_advancedNetworking(rInt int, clientId string, clientSecret string, location string, networkPlugin string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
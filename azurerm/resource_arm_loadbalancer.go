@@ -71,7 +71,7 @@ func resourceArmLoadBalancer() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Computed:     true,
-							ValidateFunc: validate.IPv4AddressOrEmpty,
+							ValidateFunc: validate.IPAddressOrEmpty,
 						},
 
 						"public_ip_address_id": {
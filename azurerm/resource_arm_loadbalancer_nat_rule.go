@@ -94,80 +94,58 @@ func resourceArmLoadBalancerNatRule() *schema.Resource {
 }
 
 func resourceArmLoadBalancerNatRuleCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).loadBalancerClient
 	ctx := meta.(*ArmClient).StopContext
 
 	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
 
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
-	if err != nil {
-		return fmt.Errorf("Error Getting Load Balancer By ID: %+v", err)
-	}
-	if !exists {
-		d.SetId("")
-		log.Printf("[INFO] Load Balancer %q not found. Removing from state", name)
-		return nil
-	}
+	read, exists, err := meta.(*ArmClient).loadBalancerBatcher.update(ctx, loadBalancerID, func(loadBalancer *network.LoadBalancer) error {
+		newNatRule, err := expandAzureRmLoadBalancerNatRule(d, loadBalancer)
+		if err != nil {
+			return fmt.Errorf("Error Expanding NAT Rule: %+v", err)
+		}
 
-	newNatRule, err := expandAzureRmLoadBalancerNatRule(d, loadBalancer)
-	if err != nil {
-		return fmt.Errorf("Error Expanding NAT Rule: %+v", err)
-	}
+		natRules := append(*loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules, *newNatRule)
 
-	natRules := append(*loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules, *newNatRule)
+		existingNatRule, existingNatRuleIndex, exists := findLoadBalancerNatRuleByName(loadBalancer, name)
+		if exists {
+			if name == *existingNatRule.Name {
+				if requireResourcesToBeImported && d.IsNewResource() {
+					return tf.ImportAsExistsError("azurerm_lb_nat_rule", *existingNatRule.ID)
+				}
 
-	existingNatRule, existingNatRuleIndex, exists := findLoadBalancerNatRuleByName(loadBalancer, name)
-	if exists {
-		if name == *existingNatRule.Name {
-			if requireResourcesToBeImported && d.IsNewResource() {
-				return tf.ImportAsExistsError("azurerm_lb_nat_rule", *existingNatRule.ID)
+				// this nat rule is being updated/reapplied remove old copy from the slice
+				natRules = append(natRules[:existingNatRuleIndex], natRules[existingNatRuleIndex+1:]...)
 			}
-
-			// this nat rule is being updated/reapplied remove old copy from the slice
-			natRules = append(natRules[:existingNatRuleIndex], natRules[existingNatRuleIndex+1:]...)
 		}
-	}
-
-	loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules = &natRules
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(loadBalancerID)
-	if err != nil {
-		return fmt.Errorf("Error Getting Load Balancer Name and Group: %+v", err)
-	}
-
-	future, err := client.CreateOrUpdate(ctx, resGroup, loadBalancerName, *loadBalancer)
-	if err != nil {
-		return fmt.Errorf("Error Creating / Updating Load Balancer %q (Resource Group %q): %+v", loadBalancerName, resGroup, err)
-	}
-
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
-		return fmt.Errorf("Error waiting for completion of Load Balancer %q (Resource Group %q): %+v", loadBalancerName, resGroup, err)
-	}
 
-	read, err := client.Get(ctx, resGroup, loadBalancerName, "")
+		loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules = &natRules
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("Error retrieving Load Balancer %q (Resource Group %q): %+v", loadBalancerName, resGroup, err)
+		return err
 	}
-
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read Load Balancer %q (Resource Group %q) ID", loadBalancerName, resGroup)
+	if !exists {
+		d.SetId("")
+		log.Printf("[INFO] Load Balancer %q not found. Removing from state", name)
+		return nil
 	}
 
 	var natRuleId string
-	for _, InboundNatRule := range *(*read.LoadBalancerPropertiesFormat).InboundNatRules {
-		if *InboundNatRule.Name == name {
-			natRuleId = *InboundNatRule.ID
+	if props := read.LoadBalancerPropertiesFormat; props != nil && props.InboundNatRules != nil {
+		for _, InboundNatRule := range *props.InboundNatRules {
+			if InboundNatRule.Name != nil && *InboundNatRule.Name == name && InboundNatRule.ID != nil {
+				natRuleId = *InboundNatRule.ID
+			}
 		}
 	}
 
-	if natRuleId != "" {
-		d.SetId(natRuleId)
-	} else {
+	if natRuleId == "" {
 		return fmt.Errorf("Cannot find created Load Balancer NAT Rule ID %q", natRuleId)
 	}
 
+	d.SetId(natRuleId)
+
 	return resourceArmLoadBalancerNatRuleRead(d, meta)
 }
 
@@ -223,51 +201,27 @@ func resourceArmLoadBalancerNatRuleRead(d *schema.ResourceData, meta interface{}
 }
 
 func resourceArmLoadBalancerNatRuleDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).loadBalancerClient
 	ctx := meta.(*ArmClient).StopContext
 
+	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
 
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
-	if err != nil {
-		return fmt.Errorf("Error Getting Load Balancer By ID: %+v", err)
-	}
-	if !exists {
-		d.SetId("")
-		return nil
-	}
+	_, exists, err := meta.(*ArmClient).loadBalancerBatcher.update(ctx, loadBalancerID, func(loadBalancer *network.LoadBalancer) error {
+		_, index, exists := findLoadBalancerNatRuleByName(loadBalancer, name)
+		if !exists {
+			return nil
+		}
 
-	_, index, exists := findLoadBalancerNatRuleByName(loadBalancer, d.Get("name").(string))
-	if !exists {
+		oldNatRules := *loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules
+		newNatRules := append(oldNatRules[:index], oldNatRules[index+1:]...)
+		loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules = &newNatRules
 		return nil
-	}
-
-	oldNatRules := *loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules
-	newNatRules := append(oldNatRules[:index], oldNatRules[index+1:]...)
-	loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules = &newNatRules
-
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(d.Get("loadbalancer_id").(string))
+	})
 	if err != nil {
-		return fmt.Errorf("Error Getting Load Balancer Name and Group: %+v", err)
-	}
-
-	future, err := client.CreateOrUpdate(ctx, resGroup, loadBalancerName, *loadBalancer)
-	if err != nil {
-		return fmt.Errorf("Error Creating/Updating Load Balancer %q (Resource Group %q) %+v", loadBalancerName, resGroup, err)
-	}
-
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
-		return fmt.Errorf("Error waiting for the completion of Load Balancer updates for %q (Resource Group %q) %+v", loadBalancerName, resGroup, err)
-	}
-
-	read, err := client.Get(ctx, resGroup, loadBalancerName, "")
-	if err != nil {
-		return fmt.Errorf("Error retrieving Load Balancer %q (Resource Group %q): %+v", loadBalancerName, resGroup, err)
+		return err
 	}
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read Load Balancer %q (resource group %q) ID", loadBalancerName, resGroup)
+	if !exists {
+		d.SetId("")
 	}
 
 	return nil
@@ -98,77 +98,55 @@ func resourceArmLoadBalancerOutboundRule() *schema.Resource {
 }
 
 func resourceArmLoadBalancerOutboundRuleCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).loadBalancerClient
 	ctx := meta.(*ArmClient).StopContext
 
 	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
 
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
-	if err != nil {
-		return fmt.Errorf("Error Getting Load Balancer By ID: %+v", err)
-	}
-	if !exists {
-		d.SetId("")
-		log.Printf("[INFO] Load Balancer %q not found. Removing from state", name)
-		return nil
-	}
+	read, exists, err := meta.(*ArmClient).loadBalancerBatcher.update(ctx, loadBalancerID, func(loadBalancer *network.LoadBalancer) error {
+		newOutboundRule, err := expandAzureRmLoadBalancerOutboundRule(d, loadBalancer)
+		if err != nil {
+			return fmt.Errorf("Error Exanding Load Balancer Rule: %+v", err)
+		}
 
-	newOutboundRule, err := expandAzureRmLoadBalancerOutboundRule(d, loadBalancer)
-	if err != nil {
-		return fmt.Errorf("Error Exanding Load Balancer Rule: %+v", err)
-	}
+		outboundRules := make([]network.OutboundRule, 0)
 
-	outboundRules := make([]network.OutboundRule, 0)
+		if loadBalancer.LoadBalancerPropertiesFormat.OutboundRules != nil {
+			outboundRules = *loadBalancer.LoadBalancerPropertiesFormat.OutboundRules
+		}
 
-	if loadBalancer.LoadBalancerPropertiesFormat.OutboundRules != nil {
-		outboundRules = *loadBalancer.LoadBalancerPropertiesFormat.OutboundRules
-	}
+		existingOutboundRule, existingOutboundRuleIndex, exists := findLoadBalancerOutboundRuleByName(loadBalancer, name)
+		if exists {
+			if name == *existingOutboundRule.Name {
+				if requireResourcesToBeImported && d.IsNewResource() {
+					return tf.ImportAsExistsError("azurerm_lb_outbound_rule", *existingOutboundRule.ID)
+				}
 
-	existingOutboundRule, existingOutboundRuleIndex, exists := findLoadBalancerOutboundRuleByName(loadBalancer, name)
-	if exists {
-		if name == *existingOutboundRule.Name {
-			if requireResourcesToBeImported && d.IsNewResource() {
-				return tf.ImportAsExistsError("azurerm_lb_outbound_rule", *existingOutboundRule.ID)
+				// this outbound rule is being updated/reapplied remove old copy from the slice
+				outboundRules = append(outboundRules[:existingOutboundRuleIndex], outboundRules[existingOutboundRuleIndex+1:]...)
 			}
-
-			// this outbound rule is being updated/reapplied remove old copy from the slice
-			outboundRules = append(outboundRules[:existingOutboundRuleIndex], outboundRules[existingOutboundRuleIndex+1:]...)
 		}
-	}
-
-	outboundRules = append(outboundRules, *newOutboundRule)
-
-	loadBalancer.LoadBalancerPropertiesFormat.OutboundRules = &outboundRules
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(loadBalancerID)
-	if err != nil {
-		return fmt.Errorf("Error Getting Load Balancer Name and Group:: %+v", err)
-	}
-
-	future, err := client.CreateOrUpdate(ctx, resGroup, loadBalancerName, *loadBalancer)
-	if err != nil {
-		return fmt.Errorf("Error Creating/Updating LoadBalancer: %+v", err)
-	}
 
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
-		return fmt.Errorf("Error waiting for completion for Load Balancer updates: %+v", err)
-	}
+		outboundRules = append(outboundRules, *newOutboundRule)
 
-	read, err := client.Get(ctx, resGroup, loadBalancerName, "")
+		loadBalancer.LoadBalancerPropertiesFormat.OutboundRules = &outboundRules
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("Error Getting LoadBalancer: %+v", err)
+		return err
 	}
-
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read Load Balancer %s (resource group %s) ID", loadBalancerName, resGroup)
+	if !exists {
+		d.SetId("")
+		log.Printf("[INFO] Load Balancer %q not found. Removing from state", name)
+		return nil
 	}
 
 	var outboundRuleId string
-	for _, OutboundRule := range *(*read.LoadBalancerPropertiesFormat).OutboundRules {
-		if *OutboundRule.Name == name {
-			outboundRuleId = *OutboundRule.ID
+	if props := read.LoadBalancerPropertiesFormat; props != nil && props.OutboundRules != nil {
+		for _, OutboundRule := range *props.OutboundRules {
+			if OutboundRule.Name != nil && *OutboundRule.Name == name && OutboundRule.ID != nil {
+				outboundRuleId = *OutboundRule.ID
+			}
 		}
 	}
 
@@ -250,51 +228,27 @@ func resourceArmLoadBalancerOutboundRuleRead(d *schema.ResourceData, meta interf
 }
 
 func resourceArmLoadBalancerOutboundRuleDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).loadBalancerClient
 	ctx := meta.(*ArmClient).StopContext
 
+	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
 
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
-	if err != nil {
-		return fmt.Errorf("Error Getting Load Balancer By ID: %+v", err)
-	}
-	if !exists {
-		d.SetId("")
-		return nil
-	}
+	_, exists, err := meta.(*ArmClient).loadBalancerBatcher.update(ctx, loadBalancerID, func(loadBalancer *network.LoadBalancer) error {
+		_, index, exists := findLoadBalancerOutboundRuleByName(loadBalancer, name)
+		if !exists {
+			return nil
+		}
 
-	_, index, exists := findLoadBalancerOutboundRuleByName(loadBalancer, d.Get("name").(string))
-	if !exists {
+		oldOutboundRules := *loadBalancer.LoadBalancerPropertiesFormat.OutboundRules
+		newOutboundRules := append(oldOutboundRules[:index], oldOutboundRules[index+1:]...)
+		loadBalancer.LoadBalancerPropertiesFormat.OutboundRules = &newOutboundRules
 		return nil
-	}
-
-	oldOutboundRules := *loadBalancer.LoadBalancerPropertiesFormat.OutboundRules
-	newOutboundRules := append(oldOutboundRules[:index], oldOutboundRules[index+1:]...)
-	loadBalancer.LoadBalancerPropertiesFormat.OutboundRules = &newOutboundRules
-
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(d.Get("loadbalancer_id").(string))
-	if err != nil {
-		return fmt.Errorf("Error Getting Load Balancer Name and Group:: %+v", err)
-	}
-
-	future, err := client.CreateOrUpdate(ctx, resGroup, loadBalancerName, *loadBalancer)
-	if err != nil {
-		return fmt.Errorf("Error Creating/Updating Load Balancer %q (Resource Group %q): %+v", loadBalancerName, resGroup, err)
-	}
-
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
-		return fmt.Errorf("Error waiting for completion of Load Balancer %q (Resource Group %q): %+v", loadBalancerName, resGroup, err)
-	}
-
-	read, err := client.Get(ctx, resGroup, loadBalancerName, "")
+	})
 	if err != nil {
-		return fmt.Errorf("Error Getting LoadBalancer: %+v", err)
+		return err
 	}
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read ID of Load Balancer %q (resource group %s)", loadBalancerName, resGroup)
+	if !exists {
+		d.SetId("")
 	}
 
 	return nil
@@ -16,9 +16,9 @@ import (
 
 func resourceArmManagedDisk() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceArmManagedDiskCreateUpdate,
+		Create: resourceArmManagedDiskCreate,
 		Read:   resourceArmManagedDiskRead,
-		Update: resourceArmManagedDiskCreateUpdate,
+		Update: resourceArmManagedDiskUpdate,
 		Delete: resourceArmManagedDiskDelete,
 
 		Importer: &schema.ResourceImporter{
@@ -97,13 +97,57 @@ func resourceArmManagedDisk() *schema.Resource {
 				ValidateFunc: validateDiskSizeGB,
 			},
 
+			"disk_iops_read_write": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"disk_mbps_read_write": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"deallocate_virtual_machine_on_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"managed_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"encryption_settings": encryptionSettingsSchema(),
 
 			"tags": tagsSchema(),
 		},
+
+		CustomizeDiff: resourceArmManagedDiskCustomizeDiff,
 	}
 }
 
+func resourceArmManagedDiskCustomizeDiff(d *schema.ResourceDiff, _ interface{}) error {
+	storageAccountType := d.Get("storage_account_type").(string)
+	if strings.EqualFold(storageAccountType, string(compute.UltraSSDLRS)) {
+		return nil
+	}
+
+	if v, ok := d.GetOk("disk_iops_read_write"); ok && d.HasChange("disk_iops_read_write") && v.(int) > 0 {
+		return fmt.Errorf("`disk_iops_read_write` can only be set when `storage_account_type` is set to `UltraSSD_LRS`")
+	}
+
+	if v, ok := d.GetOk("disk_mbps_read_write"); ok && d.HasChange("disk_mbps_read_write") && v.(int) > 0 {
+		return fmt.Errorf("`disk_mbps_read_write` can only be set when `storage_account_type` is set to `UltraSSD_LRS`")
+	}
+
+	return nil
+}
+
 func validateDiskSizeGB(v interface{}, _ string) (warnings []string, errors []error) {
 	value := v.(int)
 	if value < 0 || value > 32767 {
@@ -113,7 +157,7 @@ func validateDiskSizeGB(v interface{}, _ string) (warnings []string, errors []er
 	return warnings, errors
 }
 
-func resourceArmManagedDiskCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceArmManagedDiskCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).diskClient
 	ctx := meta.(*ArmClient).StopContext
 
@@ -142,14 +186,7 @@ func resourceArmManagedDiskCreateUpdate(d *schema.ResourceData, meta interface{}
 	expandedTags := expandTags(tags)
 	zones := expandZones(d.Get("zones").([]interface{}))
 
-	var skuName compute.DiskStorageAccountTypes
-	if strings.EqualFold(storageAccountType, string(compute.PremiumLRS)) {
-		skuName = compute.PremiumLRS
-	} else if strings.EqualFold(storageAccountType, string(compute.StandardLRS)) {
-		skuName = compute.StandardLRS
-	} else if strings.EqualFold(storageAccountType, string(compute.StandardSSDLRS)) {
-		skuName = compute.StandardSSDLRS
-	}
+	skuName := expandManagedDiskStorageAccountType(storageAccountType)
 
 	createDisk := compute.Disk{
 		Name:     &name,
@@ -169,6 +206,14 @@ func resourceArmManagedDiskCreateUpdate(d *schema.ResourceData, meta interface{}
 		createDisk.DiskProperties.DiskSizeGB = &diskSize
 	}
 
+	if v, ok := d.GetOk("disk_iops_read_write"); ok {
+		createDisk.DiskProperties.DiskIOPSReadWrite = utils.Int64(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("disk_mbps_read_write"); ok {
+		createDisk.DiskProperties.DiskMBpsReadWrite = utils.Int32(int32(v.(int)))
+	}
+
 	createOption := d.Get("create_option").(string)
 	createDisk.CreationData = &compute.CreationData{
 		CreateOption: compute.DiskCreateOption(createOption),
@@ -224,6 +269,156 @@ func resourceArmManagedDiskCreateUpdate(d *schema.ResourceData, meta interface{}
 	return resourceArmManagedDiskRead(d, meta)
 }
 
+func resourceArmManagedDiskUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).diskClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resGroup := id.ResourceGroup
+	name := id.Path["disks"]
+
+	disk, err := client.Get(ctx, resGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Managed Disk %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	update := compute.DiskUpdate{
+		DiskUpdateProperties: &compute.DiskUpdateProperties{},
+	}
+
+	if d.HasChange("storage_account_type") {
+		update.Sku = &compute.DiskSku{
+			Name: expandManagedDiskStorageAccountType(d.Get("storage_account_type").(string)),
+		}
+	}
+
+	if d.HasChange("os_type") {
+		update.DiskUpdateProperties.OsType = compute.OperatingSystemTypes(d.Get("os_type").(string))
+	}
+
+	if d.HasChange("disk_size_gb") {
+		if v, ok := d.GetOk("disk_size_gb"); ok {
+			update.DiskUpdateProperties.DiskSizeGB = utils.Int32(int32(v.(int)))
+		}
+	}
+
+	if d.HasChange("disk_iops_read_write") {
+		if v, ok := d.GetOk("disk_iops_read_write"); ok {
+			update.DiskUpdateProperties.DiskIOPSReadWrite = utils.Int64(int64(v.(int)))
+		}
+	}
+
+	if d.HasChange("disk_mbps_read_write") {
+		if v, ok := d.GetOk("disk_mbps_read_write"); ok {
+			update.DiskUpdateProperties.DiskMBpsReadWrite = utils.Int32(int32(v.(int)))
+		}
+	}
+
+	if d.HasChange("encryption_settings") {
+		if v, ok := d.GetOk("encryption_settings"); ok {
+			encryptionSettings := v.([]interface{})
+			settings := encryptionSettings[0].(map[string]interface{})
+			update.DiskUpdateProperties.EncryptionSettings = expandManagedDiskEncryptionSettings(settings)
+		}
+	}
+
+	tags := d.Get("tags").(map[string]interface{})
+	update.Tags = expandTags(tags)
+
+	// resizing a disk or changing its SKU is only possible when it's not attached to a running Virtual Machine
+	requiresDeallocation := d.HasChange("disk_size_gb") || d.HasChange("storage_account_type")
+	if requiresDeallocation && disk.ManagedBy != nil && *disk.ManagedBy != "" {
+		return resourceArmManagedDiskUpdateAttached(d, meta, *disk.ManagedBy, update)
+	}
+
+	return resourceArmManagedDiskApplyUpdate(d, meta, update)
+}
+
+// resourceArmManagedDiskUpdateAttached deallocates the Virtual Machine the disk is attached to (if required),
+// applies the update to the disk and then starts the Virtual Machine again if it was running beforehand.
+func resourceArmManagedDiskUpdateAttached(d *schema.ResourceData, meta interface{}, virtualMachineId string, update compute.DiskUpdate) error {
+	vmClient := meta.(*ArmClient).vmClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+
+	vmId, err := parseAzureResourceID(virtualMachineId)
+	if err != nil {
+		return fmt.Errorf("Error parsing Virtual Machine ID %q which Managed Disk %q is attached to: %+v", virtualMachineId, name, err)
+	}
+	vmResourceGroup := vmId.ResourceGroup
+	vmName := vmId.Path["virtualMachines"]
+
+	azureRMLockByName(vmName, virtualMachineResourceName)
+	defer azureRMUnlockByName(vmName, virtualMachineResourceName)
+
+	instanceView, err := vmClient.InstanceView(ctx, vmResourceGroup, vmName)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Instance View for Virtual Machine %q (Resource Group %q): %+v", vmName, vmResourceGroup, err)
+	}
+
+	powerState := virtualMachinePowerState(instanceView.Statuses)
+	if !virtualMachinePowerStateRequiresDeallocation(powerState) {
+		log.Printf("[DEBUG] Virtual Machine %q (Resource Group %q) is %q - updating Managed Disk %q without deallocating", vmName, vmResourceGroup, powerState, name)
+		return resourceArmManagedDiskApplyUpdate(d, meta, update)
+	}
+
+	if !d.Get("deallocate_virtual_machine_on_update").(bool) {
+		return fmt.Errorf("Managed Disk %q is attached to Virtual Machine %q (Resource Group %q) which is %q - resizing the disk or changing `storage_account_type` requires the Virtual Machine to be deallocated. Either deallocate the Virtual Machine or set `deallocate_virtual_machine_on_update` to `true`", name, vmName, vmResourceGroup, powerState)
+	}
+
+	log.Printf("[INFO] Deallocating Virtual Machine %q (Resource Group %q) to update Managed Disk %q..", vmName, vmResourceGroup, name)
+	deallocateFuture, err := vmClient.Deallocate(ctx, vmResourceGroup, vmName)
+	if err != nil {
+		return fmt.Errorf("Error deallocating Virtual Machine %q (Resource Group %q): %+v", vmName, vmResourceGroup, err)
+	}
+	if err = deallocateFuture.WaitForCompletionRef(ctx, vmClient.Client); err != nil {
+		return fmt.Errorf("Error waiting for deallocation of Virtual Machine %q (Resource Group %q): %+v", vmName, vmResourceGroup, err)
+	}
+	log.Printf("[INFO] Deallocated Virtual Machine %q (Resource Group %q).", vmName, vmResourceGroup)
+
+	updateErr := resourceArmManagedDiskApplyUpdate(d, meta, update)
+
+	// only start Virtual Machines which were running prior to the update - but do so even if the update failed
+	if powerState != "running" && powerState != "starting" {
+		return updateErr
+	}
+
+	log.Printf("[INFO] Starting Virtual Machine %q (Resource Group %q)..", vmName, vmResourceGroup)
+	startFuture, err := vmClient.Start(ctx, vmResourceGroup, vmName)
+	if err != nil {
+		return fmt.Errorf("Error starting Virtual Machine %q (Resource Group %q) after updating Managed Disk %q: %+v", vmName, vmResourceGroup, name, err)
+	}
+	if err = startFuture.WaitForCompletionRef(ctx, vmClient.Client); err != nil {
+		return fmt.Errorf("Error waiting for Virtual Machine %q (Resource Group %q) to start after updating Managed Disk %q: %+v", vmName, vmResourceGroup, name, err)
+	}
+	log.Printf("[INFO] Started Virtual Machine %q (Resource Group %q).", vmName, vmResourceGroup)
+
+	return updateErr
+}
+
+func resourceArmManagedDiskApplyUpdate(d *schema.ResourceData, meta interface{}, update compute.DiskUpdate) error {
+	client := meta.(*ArmClient).diskClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	resGroup := d.Get("resource_group_name").(string)
+
+	future, err := client.Update(ctx, resGroup, name, update)
+	if err != nil {
+		return fmt.Errorf("Error updating Managed Disk %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for update of Managed Disk %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	return resourceArmManagedDiskRead(d, meta)
+}
+
 func resourceArmManagedDiskRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).diskClient
 	ctx := meta.(*ArmClient).StopContext
@@ -263,8 +458,16 @@ func resourceArmManagedDiskRead(d *schema.ResourceData, meta interface{}) error
 		if osType := props.OsType; osType != "" {
 			d.Set("os_type", string(osType))
 		}
+		if iops := props.DiskIOPSReadWrite; iops != nil {
+			d.Set("disk_iops_read_write", int(*iops))
+		}
+		if mbps := props.DiskMBpsReadWrite; mbps != nil {
+			d.Set("disk_mbps_read_write", int(*mbps))
+		}
 	}
 
+	d.Set("managed_by", resp.ManagedBy)
+
 	if resp.CreationData != nil {
 		flattenAzureRmManagedDiskCreationData(d, resp.CreationData)
 	}
@@ -308,6 +511,45 @@ func resourceArmManagedDiskDelete(d *schema.ResourceData, meta interface{}) erro
 	return nil
 }
 
+func expandManagedDiskStorageAccountType(input string) compute.DiskStorageAccountTypes {
+	for _, v := range compute.PossibleDiskStorageAccountTypesValues() {
+		if strings.EqualFold(input, string(v)) {
+			return v
+		}
+	}
+
+	return compute.DiskStorageAccountTypes(input)
+}
+
+// virtualMachinePowerState returns the power state (e.g. `running`) from the statuses of a Virtual Machine's Instance View
+func virtualMachinePowerState(statuses *[]compute.InstanceViewStatus) string {
+	if statuses == nil {
+		return ""
+	}
+
+	for _, status := range *statuses {
+		if status.Code == nil {
+			continue
+		}
+
+		code := strings.ToLower(*status.Code)
+		if strings.HasPrefix(code, "powerstate/") {
+			return strings.TrimPrefix(code, "powerstate/")
+		}
+	}
+
+	return ""
+}
+
+func virtualMachinePowerStateRequiresDeallocation(powerState string) bool {
+	switch powerState {
+	case "deallocated", "deallocating":
+		return false
+	}
+
+	return true
+}
+
 func flattenAzureRmManagedDiskCreationData(d *schema.ResourceData, creationData *compute.CreationData) {
 	d.Set("create_option", string(creationData.CreateOption))
 	if ref := creationData.ImageReference; ref != nil {
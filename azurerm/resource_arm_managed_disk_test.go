@@ -3,6 +3,7 @@ package azurerm
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
@@ -10,8 +11,65 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+func TestAzureRMManagedDiskVirtualMachinePowerState(t *testing.T) {
+	cases := []struct {
+		Statuses             *[]compute.InstanceViewStatus
+		Expected             string
+		RequiresDeallocation bool
+	}{
+		{
+			Statuses:             nil,
+			Expected:             "",
+			RequiresDeallocation: true,
+		},
+		{
+			Statuses: &[]compute.InstanceViewStatus{
+				{Code: utils.String("ProvisioningState/succeeded")},
+				{Code: utils.String("PowerState/running")},
+			},
+			Expected:             "running",
+			RequiresDeallocation: true,
+		},
+		{
+			Statuses: &[]compute.InstanceViewStatus{
+				{Code: utils.String("ProvisioningState/succeeded")},
+				{Code: utils.String("PowerState/stopped")},
+			},
+			Expected:             "stopped",
+			RequiresDeallocation: true,
+		},
+		{
+			Statuses: &[]compute.InstanceViewStatus{
+				{Code: utils.String("PowerState/Deallocated")},
+			},
+			Expected:             "deallocated",
+			RequiresDeallocation: false,
+		},
+		{
+			Statuses: &[]compute.InstanceViewStatus{
+				{Code: nil},
+				{Code: utils.String("PowerState/deallocating")},
+			},
+			Expected:             "deallocating",
+			RequiresDeallocation: false,
+		},
+	}
+
+	for _, tc := range cases {
+		powerState := virtualMachinePowerState(tc.Statuses)
+		if powerState != tc.Expected {
+			t.Fatalf("Expected the Power State to be %q but got %q", tc.Expected, powerState)
+		}
+
+		if requiresDeallocation := virtualMachinePowerStateRequiresDeallocation(powerState); requiresDeallocation != tc.RequiresDeallocation {
+			t.Fatalf("Expected Power State %q to require deallocation to be %t but got %t", powerState, tc.RequiresDeallocation, requiresDeallocation)
+		}
+	}
+}
+
 func TestAccAzureRMManagedDisk_empty(t *testing.T) {
 	resourceName := "azurerm_managed_disk.test"
 	ri := tf.AccRandTimeInt()
@@ -30,9 +88,10 @@ func TestAccAzureRMManagedDisk_empty(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deallocate_virtual_machine_on_update"},
 			},
 		},
 	})
@@ -195,6 +254,100 @@ func TestAccAzureRMManagedDisk_update(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMManagedDisk_attachedResize(t *testing.T) {
+	resourceName := "azurerm_managed_disk.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+	var d compute.Disk
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMManagedDiskDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMManagedDisk_attached(ri, location, 10, "Standard_LRS", true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMManagedDiskExists(resourceName, &d, true),
+					resource.TestCheckResourceAttr(resourceName, "disk_size_gb", "10"),
+					resource.TestCheckResourceAttrSet(resourceName, "managed_by"),
+				),
+			},
+			{
+				Config: testAccAzureRMManagedDisk_attached(ri, location, 20, "Premium_LRS", true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMManagedDiskExists(resourceName, &d, true),
+					resource.TestCheckResourceAttr(resourceName, "disk_size_gb", "20"),
+					resource.TestCheckResourceAttr(resourceName, "storage_account_type", string(compute.StorageAccountTypesPremiumLRS)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMManagedDisk_attachedResizeWithoutDeallocation(t *testing.T) {
+	resourceName := "azurerm_managed_disk.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+	var d compute.Disk
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMManagedDiskDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMManagedDisk_attached(ri, location, 10, "Standard_LRS", false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMManagedDiskExists(resourceName, &d, true),
+					resource.TestCheckResourceAttrSet(resourceName, "managed_by"),
+				),
+			},
+			{
+				Config:      testAccAzureRMManagedDisk_attached(ri, location, 20, "Standard_LRS", false),
+				ExpectError: regexp.MustCompile("requires the Virtual Machine to be deallocated"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMManagedDisk_ultraSSD(t *testing.T) {
+	resourceName := "azurerm_managed_disk.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+	var d compute.Disk
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMManagedDiskDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMManagedDisk_ultraSSD(ri, location, 101, 10),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMManagedDiskExists(resourceName, &d, true),
+					resource.TestCheckResourceAttr(resourceName, "disk_iops_read_write", "101"),
+					resource.TestCheckResourceAttr(resourceName, "disk_mbps_read_write", "10"),
+				),
+			},
+			{
+				Config: testAccAzureRMManagedDisk_ultraSSD(ri, location, 102, 11),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMManagedDiskExists(resourceName, &d, true),
+					resource.TestCheckResourceAttr(resourceName, "disk_iops_read_write", "102"),
+					resource.TestCheckResourceAttr(resourceName, "disk_mbps_read_write", "11"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deallocate_virtual_machine_on_update"},
+			},
+		},
+	})
+}
+
 func TestAccAzureRMManagedDisk_encryption(t *testing.T) {
 	resourceName := "azurerm_managed_disk.test"
 	ri := tf.AccRandTimeInt()
@@ -267,9 +420,10 @@ func TestAccAzureRMManagedDisk_importEmpty_withZone(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deallocate_virtual_machine_on_update"},
 			},
 		},
 	})
@@ -700,3 +854,108 @@ resource "azurerm_managed_disk" "test" {
 }
 `, rInt, location, rString, rString, rString, rInt)
 }
+
+func testAccAzureRMManagedDisk_attached(rInt int, location string, diskSizeGB int, storageAccountType string, deallocate bool) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctvn-%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "acctsub-%d"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  virtual_network_name = "${azurerm_virtual_network.test.name}"
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurerm_network_interface" "test" {
+  name                = "acctni-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  ip_configuration {
+    name                          = "testconfiguration1"
+    subnet_id                     = "${azurerm_subnet.test.id}"
+    private_ip_address_allocation = "Dynamic"
+  }
+}
+
+resource "azurerm_virtual_machine" "test" {
+  name                  = "acctvm-%d"
+  location              = "${azurerm_resource_group.test.location}"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  network_interface_ids = ["${azurerm_network_interface.test.id}"]
+  vm_size               = "Standard_F2s"
+
+  storage_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  storage_os_disk {
+    name              = "myosdisk1"
+    caching           = "ReadWrite"
+    create_option     = "FromImage"
+    managed_disk_type = "Standard_LRS"
+  }
+
+  os_profile {
+    computer_name  = "hn%d"
+    admin_username = "testadmin"
+    admin_password = "Password1234!"
+  }
+
+  os_profile_linux_config {
+    disable_password_authentication = false
+  }
+}
+
+resource "azurerm_managed_disk" "test" {
+  name                                 = "acctestd-%d"
+  location                             = "${azurerm_resource_group.test.location}"
+  resource_group_name                  = "${azurerm_resource_group.test.name}"
+  storage_account_type                 = "%s"
+  create_option                        = "Empty"
+  disk_size_gb                         = %d
+  deallocate_virtual_machine_on_update = %t
+}
+
+resource "azurerm_virtual_machine_data_disk_attachment" "test" {
+  managed_disk_id    = "${azurerm_managed_disk.test.id}"
+  virtual_machine_id = "${azurerm_virtual_machine.test.id}"
+  lun                = "0"
+  caching            = "None"
+}
+`, rInt, location, rInt, rInt, rInt, rInt, rInt, rInt, storageAccountType, diskSizeGB, deallocate)
+}
+
+func testAccAzureRMManagedDisk_ultraSSD(rInt int, location string, iops int, mbps int) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_managed_disk" "test" {
+  name                 = "acctestd-%d"
+  location             = "${azurerm_resource_group.test.location}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_type = "UltraSSD_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "4"
+  disk_iops_read_write = %d
+  disk_mbps_read_write = %d
+  zones                = ["1"]
+}
+`, rInt, location, rInt, iops, mbps)
+}
@@ -632,6 +632,9 @@ func expandAzureRmNetworkInterfaceIpConfigurations(d *schema.ResourceData) ([]ne
 		}
 
 		if v := data["private_ip_address"].(string); v != "" {
+			if err := validate.IPAddressMatchesVersion(v, string(private_ip_address_version)); err != nil {
+				return nil, nil, nil, fmt.Errorf("Error validating `private_ip_address` for IP Configuration %q: %+v", data["name"].(string), err)
+			}
 			properties.PrivateIPAddress = &v
 		}
 
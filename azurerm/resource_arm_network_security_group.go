@@ -2,6 +2,7 @@ package azurerm
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
 	"github.com/hashicorp/go-multierror"
@@ -9,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/set"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
@@ -88,27 +90,35 @@ func resourceArmNetworkSecurityGroup() *schema.Resource {
 						},
 
 						"source_address_prefix": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NetworkSecurityRuleAddressPrefix,
 						},
 
 						"source_address_prefixes": {
 							Type:     schema.TypeSet,
 							Optional: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-							Set:      schema.HashString,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validate.NetworkSecurityRuleAddressPrefix,
+							},
+							Set: schema.HashString,
 						},
 
 						"destination_address_prefix": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NetworkSecurityRuleAddressPrefix,
 						},
 
 						"destination_address_prefixes": {
 							Type:     schema.TypeSet,
 							Optional: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-							Set:      schema.HashString,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validate.NetworkSecurityRuleAddressPrefix,
+							},
+							Set: schema.HashString,
 						},
 
 						"destination_application_security_group_ids": {
@@ -156,7 +166,18 @@ func resourceArmNetworkSecurityGroup() *schema.Resource {
 
 			"tags": tagsSchema(),
 		},
+
+		CustomizeDiff: resourceArmNetworkSecurityGroupCustomizeDiff,
+	}
+}
+
+func resourceArmNetworkSecurityGroupCustomizeDiff(d *schema.ResourceDiff, v interface{}) error {
+	if !d.NewValueKnown("security_rule") {
+		return nil
 	}
+
+	rules := d.Get("security_rule").(*schema.Set).List()
+	return validateNetworkSecurityRulePriorities(rules)
 }
 
 func resourceArmNetworkSecurityGroupCreateUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -485,3 +506,37 @@ func validateSecurityRule(sgRule map[string]interface{}) error {
 
 	return err.ErrorOrNil()
 }
+
+// validateNetworkSecurityRulePriorities checks that the names of the rules are unique and that no two
+// rules share the same priority in the same direction, which the API otherwise rejects at apply time
+func validateNetworkSecurityRulePriorities(rules []interface{}) error {
+	names := make(map[string]bool)
+	priorities := make(map[string]string)
+
+	for _, raw := range rules {
+		rule := raw.(map[string]interface{})
+		name := rule["name"].(string)
+		priority := rule["priority"].(int)
+		direction := rule["direction"].(string)
+
+		if name != "" {
+			if names[strings.ToLower(name)] {
+				return fmt.Errorf("More than one `security_rule` is named %q - the names of rules must be unique within a Network Security Group", name)
+			}
+			names[strings.ToLower(name)] = true
+		}
+
+		// the priority or direction may not be known until apply time
+		if priority == 0 || direction == "" {
+			continue
+		}
+
+		key := fmt.Sprintf("%d/%s", priority, strings.ToLower(direction))
+		if existing, ok := priorities[key]; ok {
+			return fmt.Errorf("The `security_rule` blocks %q and %q both have the priority %d for %s traffic - priorities must be unique for each direction within a Network Security Group", existing, name, priority, direction)
+		}
+		priorities[key] = name
+	}
+
+	return nil
+}
@@ -11,6 +11,80 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+func TestValidateNetworkSecurityRulePriorities(t *testing.T) {
+	rule := func(name string, priority int, direction string) interface{} {
+		return map[string]interface{}{
+			"name":      name,
+			"priority":  priority,
+			"direction": direction,
+		}
+	}
+
+	cases := []struct {
+		Name        string
+		Rules       []interface{}
+		ExpectError bool
+	}{
+		{
+			Name:        "No Rules",
+			Rules:       []interface{}{},
+			ExpectError: false,
+		},
+		{
+			Name: "Unique Priorities",
+			Rules: []interface{}{
+				rule("first", 100, "Inbound"),
+				rule("second", 101, "Inbound"),
+			},
+			ExpectError: false,
+		},
+		{
+			Name: "Same Priority Different Directions",
+			Rules: []interface{}{
+				rule("first", 100, "Inbound"),
+				rule("second", 100, "Outbound"),
+			},
+			ExpectError: false,
+		},
+		{
+			Name: "Same Priority Same Direction",
+			Rules: []interface{}{
+				rule("first", 100, "Inbound"),
+				rule("second", 100, "inbound"),
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Unknown Priorities",
+			Rules: []interface{}{
+				rule("first", 0, "Inbound"),
+				rule("second", 0, "Inbound"),
+			},
+			ExpectError: false,
+		},
+		{
+			Name: "Duplicate Names",
+			Rules: []interface{}{
+				rule("first", 100, "Inbound"),
+				rule("First", 200, "Outbound"),
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateNetworkSecurityRulePriorities(tc.Rules)
+			if tc.ExpectError && err == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			}
+			if !tc.ExpectError && err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+		})
+	}
+}
+
 func TestAccAzureRMNetworkSecurityGroup_basic(t *testing.T) {
 	resourceName := "azurerm_network_security_group.test"
 	rInt := tf.AccRandTimeInt()
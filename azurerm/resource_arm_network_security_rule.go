@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
@@ -84,12 +85,16 @@ func resourceArmNetworkSecurityRule() *schema.Resource {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ConflictsWith: []string{"source_address_prefixes"},
+				ValidateFunc:  validate.NetworkSecurityRuleAddressPrefix,
 			},
 
 			"source_address_prefixes": {
-				Type:          schema.TypeSet,
-				Optional:      true,
-				Elem:          &schema.Schema{Type: schema.TypeString},
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.NetworkSecurityRuleAddressPrefix,
+				},
 				Set:           schema.HashString,
 				ConflictsWith: []string{"source_address_prefix"},
 			},
@@ -98,12 +103,16 @@ func resourceArmNetworkSecurityRule() *schema.Resource {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ConflictsWith: []string{"destination_address_prefixes"},
+				ValidateFunc:  validate.NetworkSecurityRuleAddressPrefix,
 			},
 
 			"destination_address_prefixes": {
-				Type:          schema.TypeSet,
-				Optional:      true,
-				Elem:          &schema.Schema{Type: schema.TypeString},
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.NetworkSecurityRuleAddressPrefix,
+				},
 				Set:           schema.HashString,
 				ConflictsWith: []string{"destination_address_prefix"},
 			},
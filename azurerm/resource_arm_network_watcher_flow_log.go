@@ -0,0 +1,463 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+var networkWatcherResourceName = "azurerm_network_watcher"
+
+func resourceArmNetworkWatcherFlowLog() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmNetworkWatcherFlowLogCreateUpdate,
+		Read:   resourceArmNetworkWatcherFlowLogRead,
+		Update: resourceArmNetworkWatcherFlowLogCreateUpdate,
+		Delete: resourceArmNetworkWatcherFlowLogDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"network_watcher_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"network_security_group_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"storage_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+
+			"retention_policy": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"days": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+
+			"version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 2),
+			},
+
+			"traffic_analytics": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"workspace_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.UUID,
+						},
+
+						"workspace_region": {
+							Type:             schema.TypeString,
+							Required:         true,
+							StateFunc:        azureRMNormalizeLocation,
+							DiffSuppressFunc: azureRMSuppressLocationDiff,
+						},
+
+						"workspace_resource_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"interval_in_minutes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      60,
+							ValidateFunc: validation.IntInSlice([]int{10, 60}),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceArmNetworkWatcherFlowLogCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).watcherClient
+	ctx := meta.(*ArmClient).StopContext
+
+	watcherName := d.Get("network_watcher_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	networkSecurityGroupId := d.Get("network_security_group_id").(string)
+	storageAccountId := d.Get("storage_account_id").(string)
+	enabled := d.Get("enabled").(bool)
+
+	azureRMLockByName(watcherName, networkWatcherResourceName)
+	defer azureRMUnlockByName(watcherName, networkWatcherResourceName)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		statusFuture, err := client.GetFlowLogStatus(ctx, resourceGroup, watcherName, network.FlowLogStatusParameters{
+			TargetResourceID: utils.String(networkSecurityGroupId),
+		})
+		if err != nil {
+			return fmt.Errorf("Error checking for presence of existing Flow Log for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+		}
+
+		if err = statusFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for the status of the Flow Log for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+		}
+
+		existing, err := statusFuture.Result(client)
+		if err != nil {
+			return fmt.Errorf("Error retrieving the status of the Flow Log for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+		}
+
+		if props := existing.FlowLogProperties; props != nil && props.Enabled != nil && *props.Enabled {
+			watcher, err := client.Get(ctx, resourceGroup, watcherName)
+			if err != nil {
+				return fmt.Errorf("Error retrieving Network Watcher %q (Resource Group %q): %+v", watcherName, resourceGroup, err)
+			}
+
+			return tf.ImportAsExistsError("azurerm_network_watcher_flow_log", networkWatcherFlowLogID(*watcher.ID, networkSecurityGroupId))
+		}
+	}
+
+	parameters := network.FlowLogInformation{
+		TargetResourceID: utils.String(networkSecurityGroupId),
+		FlowLogProperties: &network.FlowLogProperties{
+			StorageID:       utils.String(storageAccountId),
+			Enabled:         utils.Bool(enabled),
+			RetentionPolicy: expandAzureRmNetworkWatcherFlowLogRetentionPolicy(d.Get("retention_policy").([]interface{})),
+		},
+		FlowAnalyticsConfiguration: expandAzureRmNetworkWatcherFlowLogTrafficAnalytics(d.Get("traffic_analytics").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("version"); ok {
+		parameters.FlowLogProperties.Format = &network.FlowLogFormatParameters{
+			Type:    network.JSON,
+			Version: utils.Int32(int32(v.(int))),
+		}
+	}
+
+	future, err := client.SetFlowLogConfiguration(ctx, resourceGroup, watcherName, parameters)
+	if err != nil {
+		return fmt.Errorf("Error setting Flow Log Configuration for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for Flow Log Configuration to be set for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	watcher, err := client.Get(ctx, resourceGroup, watcherName)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Network Watcher %q (Resource Group %q): %+v", watcherName, resourceGroup, err)
+	}
+
+	if watcher.ID == nil {
+		return fmt.Errorf("Cannot read Network Watcher %q (Resource Group %q) ID", watcherName, resourceGroup)
+	}
+
+	d.SetId(networkWatcherFlowLogID(*watcher.ID, networkSecurityGroupId))
+
+	return resourceArmNetworkWatcherFlowLogRead(d, meta)
+}
+
+func resourceArmNetworkWatcherFlowLogRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).watcherClient
+	ctx := meta.(*ArmClient).StopContext
+
+	flowLogId, err := parseNetworkWatcherFlowLogID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := flowLogId.ResourceGroup
+	watcherName := flowLogId.NetworkWatcherName
+	networkSecurityGroupId := flowLogId.NetworkSecurityGroupID
+
+	future, err := client.GetFlowLogStatus(ctx, resourceGroup, watcherName, network.FlowLogStatusParameters{
+		TargetResourceID: utils.String(networkSecurityGroupId),
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(autorest.Response{Response: future.Response()}) {
+			log.Printf("[WARN] Network Watcher %q (Resource Group %q) was not found - removing Flow Log from state", watcherName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Flow Log Configuration for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if utils.ResponseWasNotFound(autorest.Response{Response: future.Response()}) {
+			log.Printf("[WARN] Network Security Group %q was not found - removing Flow Log from state", networkSecurityGroupId)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error waiting for retrieval of Flow Log Configuration for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	fli, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Flow Log Configuration for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	d.Set("network_watcher_name", watcherName)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("network_security_group_id", networkSecurityGroupId)
+
+	if props := fli.FlowLogProperties; props != nil {
+		d.Set("enabled", props.Enabled)
+		d.Set("storage_account_id", props.StorageID)
+
+		if format := props.Format; format != nil && format.Version != nil {
+			d.Set("version", int(*format.Version))
+		}
+
+		if err := d.Set("retention_policy", flattenAzureRmNetworkWatcherFlowLogRetentionPolicy(props.RetentionPolicy)); err != nil {
+			return fmt.Errorf("Error setting `retention_policy`: %+v", err)
+		}
+	}
+
+	if err := d.Set("traffic_analytics", flattenAzureRmNetworkWatcherFlowLogTrafficAnalytics(fli.FlowAnalyticsConfiguration)); err != nil {
+		return fmt.Errorf("Error setting `traffic_analytics`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceArmNetworkWatcherFlowLogDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).watcherClient
+	ctx := meta.(*ArmClient).StopContext
+
+	flowLogId, err := parseNetworkWatcherFlowLogID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := flowLogId.ResourceGroup
+	watcherName := flowLogId.NetworkWatcherName
+	networkSecurityGroupId := flowLogId.NetworkSecurityGroupID
+
+	azureRMLockByName(watcherName, networkWatcherResourceName)
+	defer azureRMUnlockByName(watcherName, networkWatcherResourceName)
+
+	// Flow Logs can't be deleted, only disabled - so we retrieve the current configuration and disable it
+	statusFuture, err := client.GetFlowLogStatus(ctx, resourceGroup, watcherName, network.FlowLogStatusParameters{
+		TargetResourceID: utils.String(networkSecurityGroupId),
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(autorest.Response{Response: statusFuture.Response()}) {
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Flow Log Configuration for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	if err = statusFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if utils.ResponseWasNotFound(autorest.Response{Response: statusFuture.Response()}) {
+			return nil
+		}
+
+		return fmt.Errorf("Error waiting for retrieval of Flow Log Configuration for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	fli, err := statusFuture.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Flow Log Configuration for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	if fli.FlowLogProperties == nil {
+		return fmt.Errorf("Error disabling Flow Log for Network Security Group %q (Watcher %q / Resource Group %q): `properties` was nil", networkSecurityGroupId, watcherName, resourceGroup)
+	}
+
+	fli.FlowLogProperties.Enabled = utils.Bool(false)
+	if fli.FlowAnalyticsConfiguration != nil && fli.FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration != nil {
+		fli.FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration.Enabled = utils.Bool(false)
+	}
+
+	future, err := client.SetFlowLogConfiguration(ctx, resourceGroup, watcherName, fli)
+	if err != nil {
+		return fmt.Errorf("Error disabling Flow Log for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for the Flow Log to be disabled for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	return nil
+}
+
+type networkWatcherFlowLogResourceID struct {
+	ResourceGroup          string
+	NetworkWatcherName     string
+	NetworkSecurityGroupID string
+}
+
+// Flow Logs aren't a separate resource in Azure, so the ID is composed of the Network Watcher ID and the Network Security Group ID
+func networkWatcherFlowLogID(networkWatcherId string, networkSecurityGroupId string) string {
+	return fmt.Sprintf("%s/networkSecurityGroupId%s", networkWatcherId, networkSecurityGroupId)
+}
+
+func parseNetworkWatcherFlowLogID(input string) (*networkWatcherFlowLogResourceID, error) {
+	parts := strings.Split(input, "/networkSecurityGroupId")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Error parsing Network Watcher Flow Log ID %q: expected the format `{networkWatcherId}/networkSecurityGroupId{networkSecurityGroupId}`", input)
+	}
+
+	watcherId, err := parseAzureResourceID(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing Network Watcher ID %q: %+v", parts[0], err)
+	}
+
+	watcherName := watcherId.Path["networkWatchers"]
+	if watcherName == "" {
+		return nil, fmt.Errorf("Error parsing Network Watcher Flow Log ID %q: `networkWatchers` segment was missing", input)
+	}
+
+	if _, err := parseAzureResourceID(parts[1]); err != nil {
+		return nil, fmt.Errorf("Error parsing Network Security Group ID %q: %+v", parts[1], err)
+	}
+
+	return &networkWatcherFlowLogResourceID{
+		ResourceGroup:          watcherId.ResourceGroup,
+		NetworkWatcherName:     watcherName,
+		NetworkSecurityGroupID: parts[1],
+	}, nil
+}
+
+func expandAzureRmNetworkWatcherFlowLogRetentionPolicy(input []interface{}) *network.RetentionPolicyParameters {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+
+	return &network.RetentionPolicyParameters{
+		Enabled: utils.Bool(v["enabled"].(bool)),
+		Days:    utils.Int32(int32(v["days"].(int))),
+	}
+}
+
+func flattenAzureRmNetworkWatcherFlowLogRetentionPolicy(input *network.RetentionPolicyParameters) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	result := make(map[string]interface{})
+
+	if input.Enabled != nil {
+		result["enabled"] = *input.Enabled
+	}
+
+	if input.Days != nil {
+		result["days"] = int(*input.Days)
+	}
+
+	return []interface{}{result}
+}
+
+func expandAzureRmNetworkWatcherFlowLogTrafficAnalytics(input []interface{}) *network.TrafficAnalyticsProperties {
+	if len(input) == 0 || input[0] == nil {
+		return &network.TrafficAnalyticsProperties{
+			NetworkWatcherFlowAnalyticsConfiguration: &network.TrafficAnalyticsConfigurationProperties{
+				Enabled: utils.Bool(false),
+			},
+		}
+	}
+
+	v := input[0].(map[string]interface{})
+
+	return &network.TrafficAnalyticsProperties{
+		NetworkWatcherFlowAnalyticsConfiguration: &network.TrafficAnalyticsConfigurationProperties{
+			Enabled:                  utils.Bool(v["enabled"].(bool)),
+			WorkspaceID:              utils.String(v["workspace_id"].(string)),
+			WorkspaceRegion:          utils.String(azureRMNormalizeLocation(v["workspace_region"].(string))),
+			WorkspaceResourceID:      utils.String(v["workspace_resource_id"].(string)),
+			TrafficAnalyticsInterval: utils.Int32(int32(v["interval_in_minutes"].(int))),
+		},
+	}
+}
+
+func flattenAzureRmNetworkWatcherFlowLogTrafficAnalytics(input *network.TrafficAnalyticsProperties) []interface{} {
+	if input == nil || input.NetworkWatcherFlowAnalyticsConfiguration == nil {
+		return []interface{}{}
+	}
+
+	config := input.NetworkWatcherFlowAnalyticsConfiguration
+
+	// when Traffic Analytics has never been configured the API returns a disabled block with no workspace
+	if config.WorkspaceResourceID == nil || *config.WorkspaceResourceID == "" {
+		return []interface{}{}
+	}
+
+	result := make(map[string]interface{})
+
+	if config.Enabled != nil {
+		result["enabled"] = *config.Enabled
+	}
+
+	if config.WorkspaceID != nil {
+		result["workspace_id"] = *config.WorkspaceID
+	}
+
+	if config.WorkspaceRegion != nil {
+		result["workspace_region"] = azureRMNormalizeLocation(*config.WorkspaceRegion)
+	}
+
+	if config.WorkspaceResourceID != nil {
+		result["workspace_resource_id"] = *config.WorkspaceResourceID
+	}
+
+	interval := 60
+	if config.TrafficAnalyticsInterval != nil {
+		interval = int(*config.TrafficAnalyticsInterval)
+	}
+	result["interval_in_minutes"] = interval
+
+	return []interface{}{result}
+}
@@ -0,0 +1,198 @@
+package azurerm
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmPrivateDnsZoneVirtualNetworkLink() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmPrivateDnsZoneVirtualNetworkLinkCreateUpdate,
+		Read:   resourceArmPrivateDnsZoneVirtualNetworkLinkRead,
+		Update: resourceArmPrivateDnsZoneVirtualNetworkLinkCreateUpdate,
+		Delete: resourceArmPrivateDnsZoneVirtualNetworkLinkDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"private_dns_zone_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"virtual_network_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"registration_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"resource_group_name": resourceGroupNameDiffSuppressSchema(),
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceArmPrivateDnsZoneVirtualNetworkLinkCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).privateDnsVirtualNetworkLinksClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	resGroup := d.Get("resource_group_name").(string)
+	dnsZoneName := d.Get("private_dns_zone_name").(string)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resGroup, dnsZoneName, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing Private DNS Zone Virtual Network Link %q (Private DNS Zone %q / Resource Group %q): %s", name, dnsZoneName, resGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_private_dns_zone_virtual_network_link", *existing.ID)
+		}
+	}
+
+	location := "global"
+	vNetID := d.Get("virtual_network_id").(string)
+	registrationEnabled := d.Get("registration_enabled").(bool)
+	tags := d.Get("tags").(map[string]interface{})
+
+	parameters := privatedns.VirtualNetworkLink{
+		Location: &location,
+		Tags:     expandTags(tags),
+		VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+			VirtualNetwork: &privatedns.SubResource{
+				ID: utils.String(vNetID),
+			},
+			RegistrationEnabled: utils.Bool(registrationEnabled),
+		},
+	}
+
+	// the DNS Zone can only be modified by one operation at a time
+	azureRMLockByName(dnsZoneName, privateDnsZoneResourceName)
+	defer azureRMUnlockByName(dnsZoneName, privateDnsZoneResourceName)
+
+	etag := ""
+	ifNoneMatch := "" // set to empty to allow updates to records after creation
+	future, err := client.CreateOrUpdate(ctx, resGroup, dnsZoneName, name, parameters, etag, ifNoneMatch)
+	if err != nil {
+		return fmt.Errorf("Error creating/updating Private DNS Zone Virtual Network Link %q (Private DNS Zone %q / Resource Group %q): %s", name, dnsZoneName, resGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for creation/update of Private DNS Zone Virtual Network Link %q (Private DNS Zone %q / Resource Group %q): %s", name, dnsZoneName, resGroup, err)
+	}
+
+	resp, err := client.Get(ctx, resGroup, dnsZoneName, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Private DNS Zone Virtual Network Link %q (Private DNS Zone %q / Resource Group %q): %s", name, dnsZoneName, resGroup, err)
+	}
+
+	if resp.ID == nil {
+		return fmt.Errorf("Cannot read Private DNS Zone Virtual Network Link %q (Private DNS Zone %q / Resource Group %q) ID", name, dnsZoneName, resGroup)
+	}
+
+	d.SetId(*resp.ID)
+
+	return resourceArmPrivateDnsZoneVirtualNetworkLinkRead(d, meta)
+}
+
+func resourceArmPrivateDnsZoneVirtualNetworkLinkRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).privateDnsVirtualNetworkLinksClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resGroup := id.ResourceGroup
+	dnsZoneName := id.Path["privateDnsZones"]
+	name := id.Path["virtualNetworkLinks"]
+
+	resp, err := client.Get(ctx, resGroup, dnsZoneName, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Private DNS Zone Virtual Network Link %q (Private DNS Zone %q / Resource Group %q): %+v", name, dnsZoneName, resGroup, err)
+	}
+
+	d.Set("name", name)
+	d.Set("resource_group_name", resGroup)
+	d.Set("private_dns_zone_name", dnsZoneName)
+
+	if props := resp.VirtualNetworkLinkProperties; props != nil {
+		d.Set("registration_enabled", props.RegistrationEnabled)
+
+		if network := props.VirtualNetwork; network != nil {
+			d.Set("virtual_network_id", network.ID)
+		}
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmPrivateDnsZoneVirtualNetworkLinkDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).privateDnsVirtualNetworkLinksClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resGroup := id.ResourceGroup
+	dnsZoneName := id.Path["privateDnsZones"]
+	name := id.Path["virtualNetworkLinks"]
+
+	azureRMLockByName(dnsZoneName, privateDnsZoneResourceName)
+	defer azureRMUnlockByName(dnsZoneName, privateDnsZoneResourceName)
+
+	etag := ""
+	future, err := client.Delete(ctx, resGroup, dnsZoneName, name, etag)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Private DNS Zone Virtual Network Link %q (Private DNS Zone %q / Resource Group %q): %+v", name, dnsZoneName, resGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return fmt.Errorf("Error waiting for deletion of Private DNS Zone Virtual Network Link %q (Private DNS Zone %q / Resource Group %q): %+v", name, dnsZoneName, resGroup, err)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
@@ -126,6 +127,13 @@ func resourceArmPublicIp() *schema.Resource {
 				Computed: true,
 			},
 
+			"public_ip_prefix_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
 			"zones": singleZonesSchema(),
 
 			"tags": tagsSchema(),
@@ -169,6 +177,11 @@ func resourceArmPublicIpCreateUpdate(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
+	publicIpPrefixId := d.Get("public_ip_prefix_id").(string)
+	if publicIpPrefixId != "" && !strings.EqualFold(sku, "standard") {
+		return fmt.Errorf("A `public_ip_prefix_id` can only be used with Standard SKU public IP addresses.")
+	}
+
 	if requireResourcesToBeImported && d.IsNewResource() {
 		existing, err := client.Get(ctx, resGroup, name, "")
 		if err != nil {
@@ -214,6 +227,12 @@ func resourceArmPublicIpCreateUpdate(d *schema.ResourceData, meta interface{}) e
 		publicIp.PublicIPAddressPropertiesFormat.DNSSettings = &dnsSettings
 	}
 
+	if publicIpPrefixId != "" {
+		publicIp.PublicIPAddressPropertiesFormat.PublicIPPrefix = &network.SubResource{
+			ID: utils.String(publicIpPrefixId),
+		}
+	}
+
 	future, err := client.CreateOrUpdate(ctx, resGroup, name, publicIp)
 	if err != nil {
 		return fmt.Errorf("Error Creating/Updating Public IP %q (Resource Group %q): %+v", name, resGroup, err)
@@ -281,6 +300,12 @@ func resourceArmPublicIpRead(d *schema.ResourceData, meta interface{}) error {
 
 		d.Set("ip_address", props.IPAddress)
 		d.Set("idle_timeout_in_minutes", props.IdleTimeoutInMinutes)
+
+		publicIpPrefixId := ""
+		if prefix := props.PublicIPPrefix; prefix != nil && prefix.ID != nil {
+			publicIpPrefixId = *prefix.ID
+		}
+		d.Set("public_ip_prefix_id", publicIpPrefixId)
 	}
 
 	flattenAndSetTags(d, resp.Tags)
@@ -1,12 +1,18 @@
 package azurerm
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
@@ -80,11 +86,50 @@ func resourceArmSharedImageVersion() *schema.Resource {
 				Default:  false,
 			},
 
+			"end_of_life_date": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validate.RFC3339Time,
+				DiffSuppressFunc: suppress.RFC3339Time,
+			},
+
+			"replication_status": sharedImageVersionReplicationStatusSchema(),
+
 			"tags": tagsSchema(),
 		},
 	}
 }
 
+func sharedImageVersionReplicationStatusSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"region": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"state": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"progress": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+
+				"details": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
 func resourceArmSharedImageVersionCreateUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).galleryImageVersionsClient
 	ctx := meta.(*ArmClient).StopContext
@@ -129,6 +174,17 @@ func resourceArmSharedImageVersionCreateUpdate(d *schema.ResourceData, meta inte
 		Tags: expandTags(tags),
 	}
 
+	if v := d.Get("end_of_life_date").(string); v != "" {
+		endOfLifeDate, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("Error parsing `end_of_life_date` %q: %+v", v, err)
+		}
+
+		version.GalleryImageVersionProperties.PublishingProfile.EndOfLifeDate = &date.Time{
+			Time: endOfLifeDate,
+		}
+	}
+
 	future, err := client.CreateOrUpdate(ctx, resourceGroup, galleryName, imageName, imageVersion, version)
 	if err != nil {
 		return fmt.Errorf("Error creating Shared Image Version %q (Image %q / Gallery %q / Resource Group %q): %+v", imageVersion, imageName, galleryName, resourceGroup, err)
@@ -138,7 +194,17 @@ func resourceArmSharedImageVersionCreateUpdate(d *schema.ResourceData, meta inte
 		return fmt.Errorf("Error waiting for the creation of Shared Image Version %q (Image %q / Gallery %q / Resource Group %q): %+v", imageVersion, imageName, galleryName, resourceGroup, err)
 	}
 
-	// TODO: poll?
+	log.Printf("[DEBUG] Waiting for Shared Image Version %q (Image %q / Gallery %q / Resource Group %q) to finish replicating..", imageVersion, imageName, galleryName, resourceGroup)
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{string(compute.InProgress), string(compute.Unknown)},
+		Target:     []string{string(compute.Completed)},
+		Refresh:    sharedImageVersionReplicationStateRefreshFunc(ctx, client, resourceGroup, galleryName, imageName, imageVersion),
+		Timeout:    90 * time.Minute,
+		MinTimeout: 30 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Shared Image Version %q (Image %q / Gallery %q / Resource Group %q) to finish replicating: %+v", imageVersion, imageName, galleryName, resourceGroup, err)
+	}
 
 	read, err := client.Get(ctx, resourceGroup, galleryName, imageName, imageVersion, "")
 	if err != nil {
@@ -184,9 +250,17 @@ func resourceArmSharedImageVersionRead(d *schema.ResourceData, meta interface{})
 	}
 
 	if props := resp.GalleryImageVersionProperties; props != nil {
+		if err := d.Set("replication_status", flattenSharedImageVersionReplicationStatus(props.ReplicationStatus)); err != nil {
+			return fmt.Errorf("Error setting `replication_status`: %+v", err)
+		}
+
 		if profile := props.PublishingProfile; profile != nil {
 			d.Set("exclude_from_latest", profile.ExcludeFromLatest)
 
+			if endOfLifeDate := profile.EndOfLifeDate; endOfLifeDate != nil {
+				d.Set("end_of_life_date", endOfLifeDate.Format(time.RFC3339))
+			}
+
 			flattenedRegions := flattenSharedImageVersionTargetRegions(profile.TargetRegions)
 			if err := d.Set("target_region", flattenedRegions); err != nil {
 				return fmt.Errorf("Error setting `target_region`: %+v", err)
@@ -236,6 +310,53 @@ func resourceArmSharedImageVersionDelete(d *schema.ResourceData, meta interface{
 
 	return nil
 }
+
+func sharedImageVersionReplicationStateRefreshFunc(ctx context.Context, client compute.GalleryImageVersionsClient, resourceGroup, galleryName, imageName, imageVersion string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := client.Get(ctx, resourceGroup, galleryName, imageName, imageVersion, compute.ReplicationStatusTypesReplicationStatus)
+		if err != nil {
+			return nil, "", fmt.Errorf("Error retrieving Replication Status for Shared Image Version %q (Image %q / Gallery %q / Resource Group %q): %+v", imageVersion, imageName, galleryName, resourceGroup, err)
+		}
+
+		props := resp.GalleryImageVersionProperties
+		if props == nil || props.ReplicationStatus == nil {
+			return resp, string(compute.Unknown), nil
+		}
+
+		status := props.ReplicationStatus
+		regions := make([]string, 0)
+		if summary := status.Summary; summary != nil {
+			for _, v := range *summary {
+				region := ""
+				if v.Region != nil {
+					region = azureRMNormalizeLocation(*v.Region)
+				}
+
+				progress := int32(0)
+				if v.Progress != nil {
+					progress = *v.Progress
+				}
+
+				log.Printf("[DEBUG] Shared Image Version %q (Image %q / Gallery %q / Resource Group %q) - replication to %q is %q (%d%%)", imageVersion, imageName, galleryName, resourceGroup, region, string(v.State), progress)
+
+				if v.State == compute.ReplicationStateFailed {
+					details := ""
+					if v.Details != nil {
+						details = *v.Details
+					}
+					regions = append(regions, fmt.Sprintf("%s: %s", region, details))
+				}
+			}
+		}
+
+		if status.AggregatedState == compute.Failed {
+			return resp, string(status.AggregatedState), fmt.Errorf("Replication failed in the following regions: %s", strings.Join(regions, ", "))
+		}
+
+		return resp, string(status.AggregatedState), nil
+	}
+}
+
 func expandSharedImageVersionTargetRegions(d *schema.ResourceData) *[]compute.TargetRegion {
 	vs := d.Get("target_region").(*schema.Set)
 	results := make([]compute.TargetRegion, 0)
@@ -277,3 +398,32 @@ func flattenSharedImageVersionTargetRegions(input *[]compute.TargetRegion) []int
 
 	return results
 }
+
+func flattenSharedImageVersionReplicationStatus(input *compute.ReplicationStatus) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil || input.Summary == nil {
+		return results
+	}
+
+	for _, v := range *input.Summary {
+		output := make(map[string]interface{})
+
+		if v.Region != nil {
+			output["region"] = azureRMNormalizeLocation(*v.Region)
+		}
+
+		output["state"] = string(v.State)
+
+		if v.Progress != nil {
+			output["progress"] = int(*v.Progress)
+		}
+
+		if v.Details != nil {
+			output["details"] = *v.Details
+		}
+
+		results = append(results, output)
+	}
+
+	return results
+}
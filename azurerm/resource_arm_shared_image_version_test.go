@@ -41,6 +41,8 @@ func TestAccAzureRMSharedImageVersion_basic(t *testing.T) {
 					testCheckAzureRMSharedImageVersionExists(resourceName),
 					resource.TestCheckResourceAttrSet(resourceName, "managed_image_id"),
 					resource.TestCheckResourceAttr(resourceName, "target_region.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "replication_status.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "replication_status.0.state", "Completed"),
 				),
 			},
 			{
@@ -50,6 +52,8 @@ func TestAccAzureRMSharedImageVersion_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "managed_image_id"),
 					resource.TestCheckResourceAttr(resourceName, "target_region.#", "2"),
 					resource.TestCheckResourceAttr(resourceName, "name", "1234567890.1234567890.1234567890"),
+					resource.TestCheckResourceAttr(resourceName, "end_of_life_date", "2099-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr(resourceName, "replication_status.#", "2"),
 				),
 			},
 			{
@@ -248,6 +252,7 @@ resource "azurerm_shared_image_version" "test" {
   resource_group_name = "${azurerm_resource_group.test.name}"
   location            = "${azurerm_resource_group.test.location}"
   managed_image_id    = "${azurerm_image.test.id}"
+  end_of_life_date    = "2099-01-01T00:00:00Z"
 
   target_region {
     name                   = "${azurerm_resource_group.test.location}"
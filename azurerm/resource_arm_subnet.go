@@ -3,11 +3,14 @@ package azurerm
 import (
 	"fmt"
 	"log"
+	"net"
+	"strconv"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
@@ -39,8 +42,37 @@ func resourceArmSubnet() *schema.Resource {
 			},
 
 			"address_prefix": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"address_prefixes", "address_prefix_length"},
+			},
+
+			"address_prefixes": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"address_prefix", "address_prefix_length"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.CIDRv4OrV6,
+				},
+			},
+
+			"address_prefix_length": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.IntBetween(1, 128),
+				ConflictsWith:    []string{"address_prefix", "address_prefixes"},
+				DiffSuppressFunc: suppressArmSubnetAddressPrefixLengthDiff,
+			},
+
+			"parent_address_space": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validate.CIDRv4OrV6,
+				DiffSuppressFunc: suppressArmSubnetParentAddressSpaceDiff,
 			},
 
 			"network_security_group_id": {
@@ -116,9 +148,52 @@ func resourceArmSubnet() *schema.Resource {
 				},
 			},
 		},
+
+		CustomizeDiff: resourceArmSubnetCustomizeDiff,
 	}
 }
 
+func resourceArmSubnetCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	prefixLength := d.Get("address_prefix_length").(int)
+	if v := d.Get("parent_address_space").(string); v != "" && prefixLength == 0 && d.NewValueKnown("address_prefix_length") {
+		return fmt.Errorf("`parent_address_space` can only be specified when `address_prefix_length` is specified")
+	}
+
+	// the address prefix is allocated when the Subnet is created, so only needs checking for new Subnets
+	if d.Id() != "" || prefixLength == 0 {
+		return nil
+	}
+
+	for _, key := range []string{"resource_group_name", "virtual_network_name", "parent_address_space"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	client := meta.(*ArmClient).vnetClient
+	ctx := meta.(*ArmClient).StopContext
+
+	vnetName := d.Get("virtual_network_name").(string)
+	resGroup := d.Get("resource_group_name").(string)
+	parent := d.Get("parent_address_space").(string)
+
+	vnet, err := client.Get(ctx, resGroup, vnetName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(vnet.Response) {
+			// the Virtual Network will be created during this apply
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Virtual Network %q (Resource Group %q): %+v", vnetName, resGroup, err)
+	}
+
+	// other Subnets may be allocated before this one during the apply, so the actual prefix is picked at creation time
+	if _, err := nextAvailableArmSubnetAddressPrefix(vnet, parent, prefixLength); err != nil {
+		return fmt.Errorf("Error allocating an address prefix for Subnet in Virtual Network %q (Resource Group %q): %+v", vnetName, resGroup, err)
+	}
+
+	return d.SetNewComputed("address_prefix")
+}
+
 func resourceArmSubnetCreateUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).subnetClient
 	ctx := meta.(*ArmClient).StopContext
@@ -142,13 +217,34 @@ func resourceArmSubnetCreateUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
-	addressPrefix := d.Get("address_prefix").(string)
-
 	azureRMLockByName(vnetName, virtualNetworkResourceName)
 	defer azureRMUnlockByName(vnetName, virtualNetworkResourceName)
 
-	properties := network.SubnetPropertiesFormat{
-		AddressPrefix: &addressPrefix,
+	properties := network.SubnetPropertiesFormat{}
+
+	if prefixLength := d.Get("address_prefix_length").(int); prefixLength > 0 && d.IsNewResource() {
+		vnetClient := meta.(*ArmClient).vnetClient
+		vnet, err := vnetClient.Get(ctx, resGroup, vnetName, "")
+		if err != nil {
+			return fmt.Errorf("Error retrieving Virtual Network %q (Resource Group %q): %+v", vnetName, resGroup, err)
+		}
+
+		addressPrefix, err := nextAvailableArmSubnetAddressPrefix(vnet, d.Get("parent_address_space").(string), prefixLength)
+		if err != nil {
+			return fmt.Errorf("Error allocating an address prefix for Subnet %q (Virtual Network %q / Resource Group %q): %+v", name, vnetName, resGroup, err)
+		}
+		log.Printf("[DEBUG] Allocated the address prefix %q for Subnet %q (Virtual Network %q / Resource Group %q)", addressPrefix, name, vnetName, resGroup)
+		properties.AddressPrefix = &addressPrefix
+	} else {
+		addressPrefix, addressPrefixes, err := expandArmSubnetAddressPrefixes(d.Get("address_prefix").(string), *utils.ExpandStringArray(d.Get("address_prefixes").([]interface{})))
+		if err != nil {
+			return fmt.Errorf("Error expanding the address prefixes for Subnet %q (Virtual Network %q / Resource Group %q): %+v", name, vnetName, resGroup, err)
+		}
+		if addressPrefix == nil && addressPrefixes == nil {
+			return fmt.Errorf("One of `address_prefix`, `address_prefixes` or `address_prefix_length` must be specified for Subnet %q (Virtual Network %q / Resource Group %q)", name, vnetName, resGroup)
+		}
+		properties.AddressPrefix = addressPrefix
+		properties.AddressPrefixes = addressPrefixes
 	}
 
 	if v, ok := d.GetOk("network_security_group_id"); ok {
@@ -247,6 +343,17 @@ func resourceArmSubnetRead(d *schema.ResourceData, meta interface{}) error {
 	if props := resp.SubnetPropertiesFormat; props != nil {
 		d.Set("address_prefix", props.AddressPrefix)
 
+		// the API only returns `addressPrefixes` when the Subnet was created using multiple prefixes
+		addressPrefixes := make([]interface{}, 0)
+		if props.AddressPrefixes != nil {
+			addressPrefixes = utils.FlattenStringArray(props.AddressPrefixes)
+		} else if len(d.Get("address_prefixes").([]interface{})) > 0 && props.AddressPrefix != nil {
+			addressPrefixes = append(addressPrefixes, *props.AddressPrefix)
+		}
+		if err := d.Set("address_prefixes", addressPrefixes); err != nil {
+			return fmt.Errorf("Error setting `address_prefixes`: %+v", err)
+		}
+
 		var securityGroupId *string
 		if props.NetworkSecurityGroup != nil {
 			securityGroupId = props.NetworkSecurityGroup.ID
@@ -440,3 +547,117 @@ func flattenSubnetDelegation(delegations *[]network.Delegation) []interface{} {
 
 	return retDeles
 }
+
+// expandArmSubnetAddressPrefixes returns the address prefix(es) to send for a Subnet. The API only accepts
+// `addressPrefixes` for Subnets with multiple prefixes (e.g. dual-stack) - a single prefix is sent as `addressPrefix`.
+// Neither is returned when no prefix is specified.
+func expandArmSubnetAddressPrefixes(addressPrefix string, addressPrefixes []string) (*string, *[]string, error) {
+	if len(addressPrefixes) > 0 {
+		if err := validate.DualStackAddressPrefixes(addressPrefixes); err != nil {
+			return nil, nil, fmt.Errorf("Error validating `address_prefixes`: %+v", err)
+		}
+	}
+
+	if len(addressPrefixes) > 1 {
+		return nil, &addressPrefixes, nil
+	}
+
+	if addressPrefix == "" && len(addressPrefixes) == 1 {
+		addressPrefix = addressPrefixes[0]
+	}
+
+	if addressPrefix == "" {
+		return nil, nil, nil
+	}
+
+	return &addressPrefix, nil, nil
+}
+
+// nextAvailableArmSubnetAddressPrefix returns the first address prefix of the specified length within the
+// address space of the Virtual Network (or the parent address space, if specified) which isn't used by a Subnet
+func nextAvailableArmSubnetAddressPrefix(vnet network.VirtualNetwork, parent string, prefixLength int) (string, error) {
+	addressSpaces := make([]string, 0)
+	existing := make([]string, 0)
+
+	if props := vnet.VirtualNetworkPropertiesFormat; props != nil {
+		if props.AddressSpace != nil && props.AddressSpace.AddressPrefixes != nil {
+			addressSpaces = *props.AddressSpace.AddressPrefixes
+		}
+
+		if props.Subnets != nil {
+			for _, subnet := range *props.Subnets {
+				subnetProps := subnet.SubnetPropertiesFormat
+				if subnetProps == nil {
+					continue
+				}
+
+				if subnetProps.AddressPrefixes != nil {
+					existing = append(existing, *subnetProps.AddressPrefixes...)
+				} else if subnetProps.AddressPrefix != nil {
+					existing = append(existing, *subnetProps.AddressPrefix)
+				}
+			}
+		}
+	}
+
+	if parent == "" {
+		return validate.NextAvailableAddressPrefix(addressSpaces, existing, prefixLength)
+	}
+
+	_, parentNet, err := net.ParseCIDR(parent)
+	if err != nil {
+		return "", fmt.Errorf("`parent_address_space` %q is not a valid CIDR block: %+v", parent, err)
+	}
+
+	parentLength, _ := parentNet.Mask.Size()
+	for _, addressSpace := range addressSpaces {
+		_, addressSpaceNet, err := net.ParseCIDR(addressSpace)
+		if err != nil {
+			continue
+		}
+
+		if addressSpaceLength, _ := addressSpaceNet.Mask.Size(); addressSpaceNet.Contains(parentNet.IP) && parentLength >= addressSpaceLength {
+			return validate.NextAvailableAddressPrefix([]string{parent}, existing, prefixLength)
+		}
+	}
+
+	return "", fmt.Errorf("`parent_address_space` %q is not within the address space of the Virtual Network (%q)", parent, addressSpaces)
+}
+
+// suppressArmSubnetAddressPrefixLengthDiff suppresses the diff when `address_prefix_length` isn't in the state
+// (e.g. after an import, since it isn't returned by the API) but matches the length of the allocated `address_prefix`
+func suppressArmSubnetAddressPrefixLengthDiff(_, old, new string, d *schema.ResourceData) bool {
+	if old != "" && old != "0" {
+		return false
+	}
+
+	_, addressPrefix, err := net.ParseCIDR(d.Get("address_prefix").(string))
+	if err != nil {
+		return false
+	}
+
+	prefixLength, _ := addressPrefix.Mask.Size()
+	return new == strconv.Itoa(prefixLength)
+}
+
+// suppressArmSubnetParentAddressSpaceDiff suppresses the diff when `parent_address_space` isn't in the state
+// (e.g. after an import, since it isn't returned by the API) but the allocated `address_prefix` is within it
+func suppressArmSubnetParentAddressSpaceDiff(_, old, new string, d *schema.ResourceData) bool {
+	if old != "" {
+		return false
+	}
+
+	_, parent, err := net.ParseCIDR(new)
+	if err != nil {
+		return false
+	}
+
+	_, addressPrefix, err := net.ParseCIDR(d.Get("address_prefix").(string))
+	if err != nil {
+		return false
+	}
+
+	parentLength, _ := parent.Mask.Size()
+	prefixLength, _ := addressPrefix.Mask.Size()
+	return parent.Contains(addressPrefix.IP) && prefixLength >= parentLength
+}
@@ -3,16 +3,50 @@ package azurerm
 import (
 	"fmt"
 	"log"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+func TestAzureRMSubnet_suppressAddressPrefixLengthDiff(t *testing.T) {
+	testCases := []struct {
+		addressPrefix string
+		old           string
+		new           string
+		parentOld     string
+		parentNew     string
+		suppress      bool
+	}{
+		{addressPrefix: "10.0.128.0/25", old: "", new: "25", parentOld: "", parentNew: "10.0.128.0/17", suppress: true},
+		{addressPrefix: "10.0.128.0/25", old: "0", new: "25", parentOld: "", parentNew: "10.0.0.0/16", suppress: true},
+		{addressPrefix: "10.0.128.0/25", old: "", new: "24", parentOld: "", parentNew: "10.1.0.0/16", suppress: false},
+		{addressPrefix: "10.0.128.0/25", old: "24", new: "25", parentOld: "10.0.0.0/17", parentNew: "10.0.128.0/17", suppress: false},
+		{addressPrefix: "", old: "", new: "25", parentOld: "", parentNew: "10.0.128.0/17", suppress: false},
+	}
+
+	for _, tc := range testCases {
+		d := schema.TestResourceDataRaw(t, resourceArmSubnet().Schema, map[string]interface{}{
+			"address_prefix": tc.addressPrefix,
+		})
+
+		if v := suppressArmSubnetAddressPrefixLengthDiff("address_prefix_length", tc.old, tc.new, d); v != tc.suppress {
+			t.Fatalf("Expected the `address_prefix_length` diff from %q to %q for %q to be suppressed %t but got %t", tc.old, tc.new, tc.addressPrefix, tc.suppress, v)
+		}
+
+		if v := suppressArmSubnetParentAddressSpaceDiff("parent_address_space", tc.parentOld, tc.parentNew, d); v != tc.suppress {
+			t.Fatalf("Expected the `parent_address_space` diff from %q to %q for %q to be suppressed %t but got %t", tc.parentOld, tc.parentNew, tc.addressPrefix, tc.suppress, v)
+		}
+	}
+}
+
 func TestAccAzureRMSubnet_basic(t *testing.T) {
 	resourceName := "azurerm_subnet.test"
 	ri := tf.AccRandTimeInt()
@@ -67,6 +101,160 @@ func TestAccAzureRMSubnet_requiresImport(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMSubnet_dualStack(t *testing.T) {
+	resourceName := "azurerm_subnet.test"
+	ri := tf.AccRandTimeInt()
+	config := testAccAzureRMSubnet_dualStack(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSubnetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSubnetExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "address_prefixes.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "address_prefixes.0", "10.0.2.0/24"),
+					resource.TestCheckResourceAttr(resourceName, "address_prefixes.1", "ace:cab:deca:deed::/64"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAzureRMSubnet_nextAvailableAddressPrefix(t *testing.T) {
+	subnet := func(prefix string) network.Subnet {
+		return network.Subnet{
+			SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+				AddressPrefix: utils.String(prefix),
+			},
+		}
+	}
+
+	vnet := network.VirtualNetwork{
+		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+			AddressSpace: &network.AddressSpace{
+				AddressPrefixes: &[]string{"10.0.0.0/16", "10.1.0.0/16"},
+			},
+			Subnets: &[]network.Subnet{
+				subnet("10.0.0.0/24"),
+				subnet("10.1.0.0/24"),
+				{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						AddressPrefixes: &[]string{"10.0.1.0/24", "ace:cab:deca:deed::/64"},
+					},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		Parent       string
+		PrefixLength int
+		Expected     string
+		Error        bool
+	}{
+		{Parent: "", PrefixLength: 24, Expected: "10.0.2.0/24"},
+		{Parent: "10.1.0.0/16", PrefixLength: 24, Expected: "10.1.1.0/24"},
+		{Parent: "10.1.128.0/17", PrefixLength: 24, Expected: "10.1.128.0/24"},
+		{Parent: "10.2.0.0/16", PrefixLength: 24, Error: true},
+		{Parent: "10.0.0.0/8", PrefixLength: 24, Error: true},
+		{Parent: "10.1.0.0/24", PrefixLength: 24, Error: true},
+	}
+
+	for _, tc := range cases {
+		actual, err := nextAvailableArmSubnetAddressPrefix(vnet, tc.Parent, tc.PrefixLength)
+		if (err != nil) != tc.Error {
+			t.Fatalf("Expected an error (%t) for %q /%d but got: %+v", tc.Error, tc.Parent, tc.PrefixLength, err)
+		}
+
+		if actual != tc.Expected {
+			t.Fatalf("Expected %q for %q /%d but got %q", tc.Expected, tc.Parent, tc.PrefixLength, actual)
+		}
+	}
+}
+
+func TestAzureRMSubnet_expandAddressPrefixes(t *testing.T) {
+	cases := []struct {
+		AddressPrefix    string
+		AddressPrefixes  []string
+		ExpectedPrefix   string
+		ExpectedPrefixes []string
+		Error            bool
+	}{
+		{},
+		{AddressPrefix: "10.0.1.0/24", ExpectedPrefix: "10.0.1.0/24"},
+		{AddressPrefixes: []string{"10.0.1.0/24"}, ExpectedPrefix: "10.0.1.0/24"},
+		{AddressPrefixes: []string{"10.0.1.0/24", "ace:cab:deca:deed::/64"}, ExpectedPrefixes: []string{"10.0.1.0/24", "ace:cab:deca:deed::/64"}},
+		{AddressPrefixes: []string{"ace:cab:deca:deed::/64"}, Error: true},
+		{AddressPrefixes: []string{"10.0.1.0/24", "10.0.1.0/24"}, Error: true},
+	}
+
+	for _, tc := range cases {
+		prefix, prefixes, err := expandArmSubnetAddressPrefixes(tc.AddressPrefix, tc.AddressPrefixes)
+		if (err != nil) != tc.Error {
+			t.Fatalf("Expected an error (%t) for %q / %v but got: %+v", tc.Error, tc.AddressPrefix, tc.AddressPrefixes, err)
+		}
+
+		actual := ""
+		if prefix != nil {
+			actual = *prefix
+		}
+		if actual != tc.ExpectedPrefix {
+			t.Fatalf("Expected the address prefix %q for %q / %v but got %q", tc.ExpectedPrefix, tc.AddressPrefix, tc.AddressPrefixes, actual)
+		}
+
+		if tc.ExpectedPrefixes == nil {
+			if prefixes != nil {
+				t.Fatalf("Expected no address prefixes for %q / %v but got %v", tc.AddressPrefix, tc.AddressPrefixes, *prefixes)
+			}
+		} else if prefixes == nil || !reflect.DeepEqual(*prefixes, tc.ExpectedPrefixes) {
+			t.Fatalf("Expected the address prefixes %v for %q / %v but got %v", tc.ExpectedPrefixes, tc.AddressPrefix, tc.AddressPrefixes, prefixes)
+		}
+	}
+}
+
+func TestAccAzureRMSubnet_addressPrefixLength(t *testing.T) {
+	ri := tf.AccRandTimeInt()
+	config := testAccAzureRMSubnet_addressPrefixLength(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSubnetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSubnetExists("azurerm_subnet.first"),
+					testCheckAzureRMSubnetExists("azurerm_subnet.second"),
+					resource.TestCheckResourceAttr("azurerm_subnet.first", "address_prefix", "10.0.1.0/24"),
+					resource.TestCheckResourceAttr("azurerm_subnet.second", "address_prefix", "10.0.128.0/25"),
+				),
+			},
+			{
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:      "azurerm_subnet.second",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// these aren't returned by the API - instead the diff is suppressed when they match the `address_prefix`
+				ImportStateVerifyIgnore: []string{"address_prefix_length", "parent_address_space"},
+			},
+		},
+	})
+}
+
 func TestAccAzureRMSubnet_delegation(t *testing.T) {
 	resourceName := "azurerm_subnet.test"
 	ri := tf.AccRandTimeInt()
@@ -479,6 +667,71 @@ resource "azurerm_subnet" "import" {
 `, template)
 }
 
+func testAccAzureRMSubnet_dualStack(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestvirtnet%d"
+  address_space       = ["10.0.0.0/16", "ace:cab:deca::/48"]
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "acctestsubnet%d"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  virtual_network_name = "${azurerm_virtual_network.test.name}"
+  address_prefixes     = ["10.0.2.0/24", "ace:cab:deca:deed::/64"]
+}
+`, rInt, location, rInt, rInt)
+}
+
+func testAccAzureRMSubnet_addressPrefixLength(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestvirtnet%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_subnet" "existing" {
+  name                 = "existing"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  virtual_network_name = "${azurerm_virtual_network.test.name}"
+  address_prefix       = "10.0.0.0/24"
+}
+
+resource "azurerm_subnet" "first" {
+  name                  = "first"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  virtual_network_name  = "${azurerm_virtual_network.test.name}"
+  address_prefix_length = 24
+
+  depends_on = ["azurerm_subnet.existing"]
+}
+
+resource "azurerm_subnet" "second" {
+  name                  = "second"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  virtual_network_name  = "${azurerm_virtual_network.test.name}"
+  address_prefix_length = 25
+  parent_address_space  = "10.0.128.0/17"
+
+  depends_on = ["azurerm_subnet.first"]
+}
+`, rInt, location, rInt)
+}
+
 func testAccAzureRMSubnet_delegation(rInt int, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
@@ -105,6 +105,32 @@ func resourceArmTrafficManagerEndpoint() *schema.Resource {
 				Optional: true,
 			},
 
+			"custom_header": trafficManagerCustomHeaderSchema(),
+
+			"subnet": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"first": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.SingleIP(),
+						},
+						"last": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.SingleIP(),
+						},
+						"scope": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 128),
+						},
+					},
+				},
+			},
+
 			"endpoint_monitor_status": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -138,10 +164,15 @@ func resourceArmTrafficManagerEndpointCreateUpdate(d *schema.ResourceData, meta
 		}
 	}
 
+	props, err := getArmTrafficManagerEndpointProperties(d)
+	if err != nil {
+		return fmt.Errorf("Error expanding Traffic Manager Endpoint %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
 	params := trafficmanager.Endpoint{
 		Name:               &name,
 		Type:               &fullEndpointType,
-		EndpointProperties: getArmTrafficManagerEndpointProperties(d),
+		EndpointProperties: props,
 	}
 
 	if _, err := client.CreateOrUpdate(ctx, resourceGroup, profileName, endpointType, name, params); err != nil {
@@ -206,6 +237,14 @@ func resourceArmTrafficManagerEndpointRead(d *schema.ResourceData, meta interfac
 		d.Set("endpoint_monitor_status", props.EndpointMonitorStatus)
 		d.Set("min_child_endpoints", props.MinChildEndpoints)
 		d.Set("geo_mappings", props.GeoMapping)
+
+		if err := d.Set("custom_header", flattenArmTrafficManagerCustomHeaders(props.CustomHeaders)); err != nil {
+			return fmt.Errorf("Error setting `custom_header`: %+v", err)
+		}
+
+		if err := d.Set("subnet", flattenArmTrafficManagerEndpointSubnets(props.Subnets)); err != nil {
+			return fmt.Errorf("Error setting `subnet`: %+v", err)
+		}
 	}
 
 	return nil
@@ -237,7 +276,7 @@ func resourceArmTrafficManagerEndpointDelete(d *schema.ResourceData, meta interf
 	return nil
 }
 
-func getArmTrafficManagerEndpointProperties(d *schema.ResourceData) *trafficmanager.EndpointProperties {
+func getArmTrafficManagerEndpointProperties(d *schema.ResourceData) (*trafficmanager.EndpointProperties, error) {
 	target := d.Get("target").(string)
 	status := d.Get("endpoint_status").(string)
 
@@ -280,5 +319,73 @@ func getArmTrafficManagerEndpointProperties(d *schema.ResourceData) *trafficmana
 		endpointProps.MinChildEndpoints = &mci64
 	}
 
-	return &endpointProps
+	customHeaders := expandArmTrafficManagerCustomHeaders(d.Get("custom_header").([]interface{}))
+	endpointProps.CustomHeaders = &customHeaders
+
+	subnets, err := expandArmTrafficManagerEndpointSubnets(d.Get("subnet").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	endpointProps.Subnets = subnets
+
+	return &endpointProps, nil
+}
+
+func expandArmTrafficManagerEndpointSubnets(input []interface{}) (*[]trafficmanager.EndpointPropertiesSubnetsItem, error) {
+	results := make([]trafficmanager.EndpointPropertiesSubnetsItem, 0)
+
+	for _, v := range input {
+		subnet := v.(map[string]interface{})
+		first := subnet["first"].(string)
+		last := subnet["last"].(string)
+		scope := subnet["scope"].(int)
+
+		// a subnet is either an address range (first/last) or a CIDR block (first/scope)
+		if last != "" && scope != 0 {
+			return nil, fmt.Errorf("Only one of `last` or `scope` can be specified for the `subnet` starting at %q", first)
+		}
+
+		result := trafficmanager.EndpointPropertiesSubnetsItem{
+			First: utils.String(first),
+		}
+
+		if last != "" {
+			result.Last = utils.String(last)
+		}
+
+		if scope != 0 {
+			result.Scope = utils.Int32(int32(scope))
+		}
+
+		results = append(results, result)
+	}
+
+	return &results, nil
+}
+
+func flattenArmTrafficManagerEndpointSubnets(input *[]trafficmanager.EndpointPropertiesSubnetsItem) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, subnet := range *input {
+		result := make(map[string]interface{})
+
+		if subnet.First != nil {
+			result["first"] = *subnet.First
+		}
+
+		if subnet.Last != nil {
+			result["last"] = *subnet.Last
+		}
+
+		if subnet.Scope != nil {
+			result["scope"] = int(*subnet.Scope)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
 }
@@ -285,6 +285,38 @@ func TestAccAzureRMTrafficManagerEndpoint_withGeoMappings(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMTrafficManagerEndpoint_subnets(t *testing.T) {
+	resourceName := "azurerm_traffic_manager_endpoint.test"
+	ri := tf.AccRandTimeInt()
+	config := testAccAzureRMTrafficManagerEndpoint_subnets(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMTrafficManagerEndpointDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMTrafficManagerEndpointExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "subnet.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "subnet.0.first", "1.2.3.0"),
+					resource.TestCheckResourceAttr(resourceName, "subnet.0.scope", "24"),
+					resource.TestCheckResourceAttr(resourceName, "subnet.1.first", "11.12.13.14"),
+					resource.TestCheckResourceAttr(resourceName, "subnet.1.last", "11.12.13.20"),
+					resource.TestCheckResourceAttr(resourceName, "custom_header.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "custom_header.0.name", "host"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testCheckAzureRMTrafficManagerEndpointExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// Ensure we have enough information in state to look up in API
@@ -875,3 +907,52 @@ resource "azurerm_traffic_manager_endpoint" "test" {
 }
 `, rInt, location, rInt, rInt)
 }
+
+func testAccAzureRMTrafficManagerEndpoint_subnets(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_traffic_manager_profile" "test" {
+  name                   = "acctesttmp%d"
+  resource_group_name    = "${azurerm_resource_group.test.name}"
+  traffic_routing_method = "Subnet"
+
+  dns_config {
+    relative_name = "acctesttmp%d"
+    ttl           = 100
+  }
+
+  monitor_config {
+    protocol = "http"
+    port     = 80
+    path     = "/"
+  }
+}
+
+resource "azurerm_traffic_manager_endpoint" "test" {
+  name                = "example.com"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  profile_name        = "${azurerm_traffic_manager_profile.test.name}"
+  target              = "example.com"
+  type                = "externalEndpoints"
+
+  subnet {
+    first = "1.2.3.0"
+    scope = 24
+  }
+
+  subnet {
+    first = "11.12.13.14"
+    last  = "11.12.13.20"
+  }
+
+  custom_header {
+    name  = "host"
+    value = "www.example.com"
+  }
+}
+`, rInt, location, rInt, rInt)
+}
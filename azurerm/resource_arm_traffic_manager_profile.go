@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/trafficmanager/mgmt/2018-04-01/trafficmanager"
@@ -11,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
@@ -107,6 +110,36 @@ func resourceArmTrafficManagerProfile() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
+						"expected_status_code_ranges": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringMatch(
+									regexp.MustCompile(`^[1-5][0-9][0-9]-[1-5][0-9][0-9]$`),
+									"`expected_status_code_ranges` must be a range of HTTP status codes, such as `200-299`",
+								),
+							},
+						},
+						"custom_header": trafficManagerCustomHeaderSchema(),
+						"interval_in_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      30,
+							ValidateFunc: validation.IntInSlice([]int{10, 30}),
+						},
+						"timeout_in_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntBetween(5, 10),
+						},
+						"tolerated_number_of_failures": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      3,
+							ValidateFunc: validation.IntBetween(0, 9),
+						},
 					},
 				},
 				Set: resourceAzureRMTrafficManagerMonitorConfigHash,
@@ -142,10 +175,15 @@ func resourceArmTrafficManagerProfileCreateUpdate(d *schema.ResourceData, meta i
 		}
 	}
 
+	props, err := getArmTrafficManagerProfileProperties(d)
+	if err != nil {
+		return fmt.Errorf("Error expanding Traffic Manager Profile %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
 	profile := trafficmanager.Profile{
 		Name:              &name,
 		Location:          &location,
-		ProfileProperties: getArmTrafficManagerProfileProperties(d),
+		ProfileProperties: props,
 		Tags:              expandTags(tags),
 	}
 
@@ -229,12 +267,17 @@ func resourceArmTrafficManagerProfileDelete(d *schema.ResourceData, meta interfa
 	return nil
 }
 
-func getArmTrafficManagerProfileProperties(d *schema.ResourceData) *trafficmanager.ProfileProperties {
+func getArmTrafficManagerProfileProperties(d *schema.ResourceData) (*trafficmanager.ProfileProperties, error) {
+	monitorConfig, err := expandArmTrafficManagerMonitorConfig(d)
+	if err != nil {
+		return nil, err
+	}
+
 	routingMethod := d.Get("traffic_routing_method").(string)
 	props := &trafficmanager.ProfileProperties{
 		TrafficRoutingMethod: trafficmanager.TrafficRoutingMethod(routingMethod),
 		DNSConfig:            expandArmTrafficManagerDNSConfig(d),
-		MonitorConfig:        expandArmTrafficManagerMonitorConfig(d),
+		MonitorConfig:        monitorConfig,
 	}
 
 	if status, ok := d.GetOk("profile_status"); ok {
@@ -242,22 +285,80 @@ func getArmTrafficManagerProfileProperties(d *schema.ResourceData) *trafficmanag
 		props.ProfileStatus = trafficmanager.ProfileStatus(s)
 	}
 
-	return props
+	return props, nil
 }
 
-func expandArmTrafficManagerMonitorConfig(d *schema.ResourceData) *trafficmanager.MonitorConfig {
+func expandArmTrafficManagerMonitorConfig(d *schema.ResourceData) (*trafficmanager.MonitorConfig, error) {
 	monitorSets := d.Get("monitor_config").(*schema.Set).List()
 	monitor := monitorSets[0].(map[string]interface{})
 
 	proto := monitor["protocol"].(string)
 	port := int64(monitor["port"].(int))
 	path := monitor["path"].(string)
+	interval := int64(monitor["interval_in_seconds"].(int))
+	timeout := int64(monitor["timeout_in_seconds"].(int))
+	toleratedFailures := int64(monitor["tolerated_number_of_failures"].(int))
+
+	if timeout >= interval {
+		return nil, fmt.Errorf("`timeout_in_seconds` (%d) must be less than `interval_in_seconds` (%d)", timeout, interval)
+	}
+
+	statusCodeRanges, err := expandArmTrafficManagerExpectedStatusCodeRanges(monitor["expected_status_code_ranges"].([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	customHeaders := make([]trafficmanager.MonitorConfigCustomHeadersItem, 0)
+	for _, header := range expandArmTrafficManagerCustomHeaders(monitor["custom_header"].([]interface{})) {
+		customHeaders = append(customHeaders, trafficmanager.MonitorConfigCustomHeadersItem{
+			Name:  header.Name,
+			Value: header.Value,
+		})
+	}
 
 	return &trafficmanager.MonitorConfig{
-		Protocol: trafficmanager.MonitorProtocol(proto),
-		Port:     &port,
-		Path:     &path,
+		Protocol:                  trafficmanager.MonitorProtocol(proto),
+		Port:                      &port,
+		Path:                      &path,
+		IntervalInSeconds:         &interval,
+		TimeoutInSeconds:          &timeout,
+		ToleratedNumberOfFailures: &toleratedFailures,
+		ExpectedStatusCodeRanges:  statusCodeRanges,
+		CustomHeaders:             &customHeaders,
+	}, nil
+}
+
+func expandArmTrafficManagerExpectedStatusCodeRanges(input []interface{}) (*[]trafficmanager.MonitorConfigExpectedStatusCodeRangesItem, error) {
+	results := make([]trafficmanager.MonitorConfigExpectedStatusCodeRangesItem, 0)
+
+	for _, v := range input {
+		statusCodeRange := v.(string)
+		parts := strings.Split(statusCodeRange, "-")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("`expected_status_code_ranges` must be in the format `min-max` but got %q", statusCodeRange)
+		}
+
+		min, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing the minimum status code from %q: %+v", statusCodeRange, err)
+		}
+
+		max, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing the maximum status code from %q: %+v", statusCodeRange, err)
+		}
+
+		if min > max {
+			return nil, fmt.Errorf("The minimum status code must not be greater than the maximum status code in %q", statusCodeRange)
+		}
+
+		results = append(results, trafficmanager.MonitorConfigExpectedStatusCodeRangesItem{
+			Min: utils.Int32(int32(min)),
+			Max: utils.Int32(int32(max)),
+		})
 	}
+
+	return &results, nil
 }
 
 func expandArmTrafficManagerDNSConfig(d *schema.ResourceData) *trafficmanager.DNSConfig {
@@ -292,9 +393,103 @@ func flattenAzureRMTrafficManagerProfileMonitorConfig(cfg *trafficmanager.Monito
 		result["path"] = *cfg.Path
 	}
 
+	if cfg.IntervalInSeconds != nil {
+		result["interval_in_seconds"] = int(*cfg.IntervalInSeconds)
+	}
+
+	if cfg.TimeoutInSeconds != nil {
+		result["timeout_in_seconds"] = int(*cfg.TimeoutInSeconds)
+	}
+
+	if cfg.ToleratedNumberOfFailures != nil {
+		result["tolerated_number_of_failures"] = int(*cfg.ToleratedNumberOfFailures)
+	}
+
+	statusCodeRanges := make([]interface{}, 0)
+	if cfg.ExpectedStatusCodeRanges != nil {
+		for _, r := range *cfg.ExpectedStatusCodeRanges {
+			if r.Min == nil || r.Max == nil {
+				continue
+			}
+
+			statusCodeRanges = append(statusCodeRanges, fmt.Sprintf("%d-%d", *r.Min, *r.Max))
+		}
+	}
+	result["expected_status_code_ranges"] = statusCodeRanges
+
+	customHeaders := make([]trafficmanager.EndpointPropertiesCustomHeadersItem, 0)
+	if cfg.CustomHeaders != nil {
+		for _, header := range *cfg.CustomHeaders {
+			customHeaders = append(customHeaders, trafficmanager.EndpointPropertiesCustomHeadersItem{
+				Name:  header.Name,
+				Value: header.Value,
+			})
+		}
+	}
+	result["custom_header"] = flattenArmTrafficManagerCustomHeaders(&customHeaders)
+
 	return []interface{}{result}
 }
 
+func trafficManagerCustomHeaderSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+				"value": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+			},
+		},
+	}
+}
+
+// the custom headers on both Monitor Configs and Endpoints share the Endpoint type
+func expandArmTrafficManagerCustomHeaders(input []interface{}) []trafficmanager.EndpointPropertiesCustomHeadersItem {
+	results := make([]trafficmanager.EndpointPropertiesCustomHeadersItem, 0)
+
+	for _, v := range input {
+		header := v.(map[string]interface{})
+		results = append(results, trafficmanager.EndpointPropertiesCustomHeadersItem{
+			Name:  utils.String(header["name"].(string)),
+			Value: utils.String(header["value"].(string)),
+		})
+	}
+
+	return results
+}
+
+func flattenArmTrafficManagerCustomHeaders(input *[]trafficmanager.EndpointPropertiesCustomHeadersItem) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, header := range *input {
+		result := make(map[string]interface{})
+
+		if header.Name != nil {
+			result["name"] = *header.Name
+		}
+
+		if header.Value != nil {
+			result["value"] = *header.Value
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
 func resourceAzureRMTrafficManagerDNSConfigHash(v interface{}) int {
 	var buf bytes.Buffer
 
@@ -316,6 +511,32 @@ func resourceAzureRMTrafficManagerMonitorConfigHash(v interface{}) int {
 		if v, ok := m["path"]; ok && v != "" {
 			buf.WriteString(fmt.Sprintf("%s-", m["path"].(string)))
 		}
+
+		// the health check settings are only included when they differ from the defaults, to keep the hash stable
+		if v, ok := m["interval_in_seconds"]; ok && v.(int) != 0 && v.(int) != 30 {
+			buf.WriteString(fmt.Sprintf("interval:%d-", v.(int)))
+		}
+
+		if v, ok := m["timeout_in_seconds"]; ok && v.(int) != 0 && v.(int) != 10 {
+			buf.WriteString(fmt.Sprintf("timeout:%d-", v.(int)))
+		}
+
+		if v, ok := m["tolerated_number_of_failures"]; ok && v.(int) != 3 {
+			buf.WriteString(fmt.Sprintf("failures:%d-", v.(int)))
+		}
+
+		if v, ok := m["expected_status_code_ranges"]; ok {
+			for _, statusCodeRange := range v.([]interface{}) {
+				buf.WriteString(fmt.Sprintf("%s-", statusCodeRange.(string)))
+			}
+		}
+
+		if v, ok := m["custom_header"]; ok {
+			for _, raw := range v.([]interface{}) {
+				header := raw.(map[string]interface{})
+				buf.WriteString(fmt.Sprintf("%s:%s-", header["name"].(string), header["value"].(string)))
+			}
+		}
 	}
 
 	return hashcode.String(buf.String())
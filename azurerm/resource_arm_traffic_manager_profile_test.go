@@ -137,6 +137,32 @@ func TestAccAzureRMTrafficManagerProfile_weightedTCP(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMTrafficManagerProfile_customMonitorConfig(t *testing.T) {
+	resourceName := "azurerm_traffic_manager_profile.test"
+	ri := tf.AccRandTimeInt()
+	config := testAccAzureRMTrafficManagerProfile_customMonitorConfig(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMTrafficManagerProfileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMTrafficManagerProfileExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "monitor_config.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccAzureRMTrafficManagerProfile_performance(t *testing.T) {
 	resourceName := "azurerm_traffic_manager_profile.test"
 	ri := tf.AccRandTimeInt()
@@ -577,3 +603,38 @@ resource "azurerm_traffic_manager_profile" "test" {
 }
 `, rInt, location, rInt, rInt)
 }
+
+func testAccAzureRMTrafficManagerProfile_customMonitorConfig(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_traffic_manager_profile" "test" {
+  name                   = "acctesttmp%d"
+  resource_group_name    = "${azurerm_resource_group.test.name}"
+  traffic_routing_method = "Subnet"
+
+  dns_config {
+    relative_name = "acctesttmp%d"
+    ttl           = 30
+  }
+
+  monitor_config {
+    protocol                     = "https"
+    port                         = 443
+    path                         = "/health"
+    expected_status_code_ranges  = ["200-202", "301-302"]
+    interval_in_seconds          = 30
+    timeout_in_seconds           = 9
+    tolerated_number_of_failures = 5
+
+    custom_header {
+      name  = "host"
+      value = "www.example.com"
+    }
+  }
+}
+`, rInt, location, rInt, rInt)
+}
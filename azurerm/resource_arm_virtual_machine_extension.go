@@ -2,6 +2,7 @@ package azurerm
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -74,6 +75,11 @@ func resourceArmVirtualMachineExtensions() *schema.Resource {
 				DiffSuppressFunc: structure.SuppressJsonDiff,
 			},
 
+			"force_update_tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
@@ -105,6 +111,7 @@ func resourceArmVirtualMachineExtensionsCreateUpdate(d *schema.ResourceData, met
 	extensionType := d.Get("type").(string)
 	typeHandlerVersion := d.Get("type_handler_version").(string)
 	autoUpgradeMinor := d.Get("auto_upgrade_minor_version").(bool)
+	forceUpdateTag := d.Get("force_update_tag").(string)
 	tags := d.Get("tags").(map[string]interface{})
 
 	extension := compute.VirtualMachineExtension{
@@ -118,6 +125,10 @@ func resourceArmVirtualMachineExtensionsCreateUpdate(d *schema.ResourceData, met
 		Tags: expandTags(tags),
 	}
 
+	if forceUpdateTag != "" {
+		extension.VirtualMachineExtensionProperties.ForceUpdateTag = utils.String(forceUpdateTag)
+	}
+
 	if settingsString := d.Get("settings").(string); settingsString != "" {
 		settings, err := structure.ExpandJsonFromString(settingsString)
 		if err != nil {
@@ -140,6 +151,14 @@ func resourceArmVirtualMachineExtensionsCreateUpdate(d *schema.ResourceData, met
 	}
 
 	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		// the Instance View contains the details of why the extension failed to provision
+		instanceView, getErr := client.Get(ctx, resGroup, vmName, name, "instanceView")
+		if getErr == nil {
+			if messages := flattenVirtualMachineExtensionInstanceViewMessages(instanceView.VirtualMachineExtensionProperties); messages != "" {
+				return fmt.Errorf("Error waiting for Virtual Machine Extension %q (Virtual Machine %q / Resource Group %q) to be provisioned: %+v\n\nInstance View:\n%s", name, vmName, resGroup, err, messages)
+			}
+		}
+
 		return err
 	}
 
@@ -191,6 +210,7 @@ func resourceArmVirtualMachineExtensionsRead(d *schema.ResourceData, meta interf
 		d.Set("type", props.Type)
 		d.Set("type_handler_version", props.TypeHandlerVersion)
 		d.Set("auto_upgrade_minor_version", props.AutoUpgradeMinorVersion)
+		d.Set("force_update_tag", props.ForceUpdateTag)
 
 		if settings := props.Settings; settings != nil {
 			settingsVal := settings.(map[string]interface{})
@@ -226,3 +246,39 @@ func resourceArmVirtualMachineExtensionsDelete(d *schema.ResourceData, meta inte
 
 	return future.WaitForCompletionRef(ctx, client.Client)
 }
+
+func flattenVirtualMachineExtensionInstanceViewMessages(props *compute.VirtualMachineExtensionProperties) string {
+	if props == nil || props.InstanceView == nil {
+		return ""
+	}
+
+	messages := make([]string, 0)
+	instanceView := props.InstanceView
+	for _, statuses := range []*[]compute.InstanceViewStatus{instanceView.Statuses, instanceView.Substatuses} {
+		if statuses == nil {
+			continue
+		}
+
+		for _, status := range *statuses {
+			code := ""
+			if status.Code != nil {
+				code = *status.Code
+			}
+
+			message := ""
+			if status.Message != nil {
+				message = *status.Message
+			} else if status.DisplayStatus != nil {
+				message = *status.DisplayStatus
+			}
+
+			if code == "" && message == "" {
+				continue
+			}
+
+			messages = append(messages, fmt.Sprintf("- [%s] %s: %s", string(status.Level), code, message))
+		}
+	}
+
+	return strings.Join(messages, "\n")
+}
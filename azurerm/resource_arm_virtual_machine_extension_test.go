@@ -6,11 +6,44 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+func TestAzureRMVirtualMachineExtensionInstanceViewMessages(t *testing.T) {
+	if messages := flattenVirtualMachineExtensionInstanceViewMessages(nil); messages != "" {
+		t.Fatalf("Expected no messages but got %q", messages)
+	}
+
+	props := &compute.VirtualMachineExtensionProperties{
+		InstanceView: &compute.VirtualMachineExtensionInstanceView{
+			Statuses: &[]compute.InstanceViewStatus{
+				{
+					Code:    utils.String("ProvisioningState/failed/1"),
+					Level:   compute.Error,
+					Message: utils.String("Enable failed: failed to execute command"),
+				},
+			},
+			Substatuses: &[]compute.InstanceViewStatus{
+				{
+					Code:    utils.String("ComponentStatus/StdErr/succeeded"),
+					Level:   compute.Info,
+					Message: utils.String("command not found"),
+				},
+				{},
+			},
+		},
+	}
+
+	expected := "- [Error] ProvisioningState/failed/1: Enable failed: failed to execute command\n- [Info] ComponentStatus/StdErr/succeeded: command not found"
+	if messages := flattenVirtualMachineExtensionInstanceViewMessages(props); messages != expected {
+		t.Fatalf("Expected %q but got %q", expected, messages)
+	}
+}
+
 func TestAccAzureRMVirtualMachineExtension_basic(t *testing.T) {
 	resourceName := "azurerm_virtual_machine_extension.test"
 	ri := tf.AccRandTimeInt()
@@ -47,6 +80,40 @@ func TestAccAzureRMVirtualMachineExtension_basic(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMVirtualMachineExtension_protectedSettings(t *testing.T) {
+	resourceName := "azurerm_virtual_machine_extension.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineExtensionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMVirtualMachineExtension_protectedSettings(ri, location, "hostname", "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineExtensionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "force_update_tag", "first"),
+				),
+			},
+			{
+				Config: testAccAzureRMVirtualMachineExtension_protectedSettings(ri, location, "whoami", "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineExtensionExists(resourceName),
+				),
+			},
+			{
+				Config: testAccAzureRMVirtualMachineExtension_protectedSettings(ri, location, "whoami", "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineExtensionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "force_update_tag", "second"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAzureRMVirtualMachineExtension_requiresImport(t *testing.T) {
 	if !requireResourcesToBeImported {
 		t.Skip("Skipping since resources aren't required to be imported")
@@ -631,3 +698,112 @@ SETTINGS
 }
 `, rInt, location, rInt, rInt, rInt, rInt, rInt, rInt, rInt)
 }
+
+func testAccAzureRMVirtualMachineExtension_protectedSettings(rInt int, location string, command string, forceUpdateTag string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctvn-%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "acctsub-%d"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  virtual_network_name = "${azurerm_virtual_network.test.name}"
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurerm_network_interface" "test" {
+  name                = "acctni-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  ip_configuration {
+    name                          = "testconfiguration1"
+    subnet_id                     = "${azurerm_subnet.test.id}"
+    private_ip_address_allocation = "Dynamic"
+  }
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "accsa%d"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  tags = {
+    environment = "staging"
+  }
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "vhds"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  storage_account_name  = "${azurerm_storage_account.test.name}"
+  container_access_type = "private"
+}
+
+resource "azurerm_virtual_machine" "test" {
+  name                  = "acctvm-%d"
+  location              = "${azurerm_resource_group.test.location}"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  network_interface_ids = ["${azurerm_network_interface.test.id}"]
+  vm_size               = "Standard_F2"
+
+  storage_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  storage_os_disk {
+    name          = "myosdisk1"
+    vhd_uri       = "${azurerm_storage_account.test.primary_blob_endpoint}${azurerm_storage_container.test.name}/myosdisk1.vhd"
+    caching       = "ReadWrite"
+    create_option = "FromImage"
+  }
+
+  os_profile {
+    computer_name  = "hostname%d"
+    admin_username = "testadmin"
+    admin_password = "Password1234!"
+  }
+
+  os_profile_linux_config {
+    disable_password_authentication = false
+  }
+}
+
+resource "azurerm_virtual_machine_extension" "test" {
+  name                 = "acctvme-%d"
+  location             = "${azurerm_resource_group.test.location}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  virtual_machine_name = "${azurerm_virtual_machine.test.name}"
+  publisher            = "Microsoft.Azure.Extensions"
+  type                 = "CustomScript"
+  type_handler_version = "2.0"
+  force_update_tag     = "%s"
+
+  settings = <<SETTINGS
+	{
+		"skipDos2Unix": true
+	}
+SETTINGS
+
+  protected_settings = <<SETTINGS
+	{
+		"commandToExecute": "%s"
+	}
+SETTINGS
+}
+`, rInt, location, rInt, rInt, rInt, rInt, rInt, rInt, rInt, forceUpdateTag, command)
+}
@@ -92,9 +92,19 @@ func resourceArmVirtualNetwork() *schema.Resource {
 						},
 						"address_prefix": {
 							Type:         schema.TypeString,
-							Required:     true,
+							Optional:     true,
+							Computed:     true,
 							ValidateFunc: validate.NoEmptyStrings,
 						},
+						"address_prefixes": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validate.CIDRv4OrV6,
+							},
+						},
 						"security_group": {
 							Type:     schema.TypeString,
 							Optional: true,
@@ -290,6 +300,7 @@ func expandVirtualNetworkProperties(ctx context.Context, d *schema.ResourceData,
 			log.Printf("[INFO] Completed GET of Subnet props ")
 
 			prefix := subnet["address_prefix"].(string)
+			prefixes := utils.ExpandStringArray(subnet["address_prefixes"].([]interface{}))
 			secGroup := subnet["security_group"].(string)
 
 			//set the props from config and leave the rest intact
@@ -298,7 +309,15 @@ func expandVirtualNetworkProperties(ctx context.Context, d *schema.ResourceData,
 				subnetObj.SubnetPropertiesFormat = &network.SubnetPropertiesFormat{}
 			}
 
-			subnetObj.SubnetPropertiesFormat.AddressPrefix = &prefix
+			addressPrefix, addressPrefixes, err := expandArmSubnetAddressPrefixes(prefix, *prefixes)
+			if err != nil {
+				return nil, fmt.Errorf("Error expanding the address prefixes for Subnet %q: %+v", name, err)
+			}
+			if addressPrefix == nil && addressPrefixes == nil {
+				return nil, fmt.Errorf("One of `address_prefix` or `address_prefixes` must be specified for Subnet %q", name)
+			}
+			subnetObj.SubnetPropertiesFormat.AddressPrefix = addressPrefix
+			subnetObj.SubnetPropertiesFormat.AddressPrefixes = addressPrefixes
 
 			if secGroup != "" {
 				subnetObj.SubnetPropertiesFormat.NetworkSecurityGroup = &network.SecurityGroup{
@@ -381,9 +400,19 @@ func flattenVirtualNetworkSubnets(input *[]network.Subnet) *schema.Set {
 			}
 
 			if props := subnet.SubnetPropertiesFormat; props != nil {
+				// Subnets with multiple prefixes (e.g. dual-stack) only return `addressPrefixes`
+				addressPrefixes := make([]interface{}, 0)
+				if prefixes := props.AddressPrefixes; prefixes != nil && len(*prefixes) > 0 {
+					addressPrefixes = utils.FlattenStringArray(prefixes)
+					output["address_prefix"] = (*prefixes)[0]
+				}
 				if prefix := props.AddressPrefix; prefix != nil {
 					output["address_prefix"] = *prefix
+					if len(addressPrefixes) == 0 {
+						addressPrefixes = append(addressPrefixes, *prefix)
+					}
 				}
+				output["address_prefixes"] = addressPrefixes
 
 				if nsg := props.NetworkSecurityGroup; nsg != nil {
 					if nsg.ID != nil {
@@ -416,10 +445,28 @@ func resourceAzureSubnetHash(v interface{}) int {
 
 	if m, ok := v.(map[string]interface{}); ok {
 		buf.WriteString(m["name"].(string))
-		buf.WriteString(m["address_prefix"].(string))
 
-		if v, ok := m["security_group"]; ok {
-			buf.WriteString(v.(string))
+		// `address_prefix` is computed for Subnets with multiple prefixes, so only the prefixes are hashed
+		var prefixes []interface{}
+		if v, ok := m["address_prefixes"].([]interface{}); ok {
+			prefixes = v
+		}
+		if len(prefixes) > 1 {
+			for _, prefix := range prefixes {
+				if v, ok := prefix.(string); ok {
+					buf.WriteString(fmt.Sprintf("%s,", v))
+				}
+			}
+		} else if v, ok := m["address_prefix"].(string); ok && v != "" {
+			buf.WriteString(v)
+		} else if len(prefixes) == 1 {
+			if v, ok := prefixes[0].(string); ok {
+				buf.WriteString(v)
+			}
+		}
+
+		if v, ok := m["security_group"].(string); ok {
+			buf.WriteString(v)
 		}
 	}
 
@@ -108,117 +108,7 @@ func resourceArmVirtualNetworkGatewayConnection() *schema.Resource {
 				Sensitive: true,
 			},
 
-			"ipsec_policy": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"dh_group": {
-							Type:             schema.TypeString,
-							Required:         true,
-							DiffSuppressFunc: suppress.CaseDifference,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(network.DHGroup1),
-								string(network.DHGroup14),
-								string(network.DHGroup2),
-								string(network.DHGroup2048),
-								string(network.DHGroup24),
-								string(network.ECP256),
-								string(network.ECP384),
-								string(network.None),
-							}, true),
-						},
-
-						"ike_encryption": {
-							Type:             schema.TypeString,
-							Required:         true,
-							DiffSuppressFunc: suppress.CaseDifference,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(network.AES128),
-								string(network.AES192),
-								string(network.AES256),
-								string(network.DES),
-								string(network.DES3),
-							}, true),
-						},
-
-						"ike_integrity": {
-							Type:             schema.TypeString,
-							Required:         true,
-							DiffSuppressFunc: suppress.CaseDifference,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(network.IkeIntegrityGCMAES128),
-								string(network.IkeIntegrityGCMAES256),
-								string(network.IkeIntegrityMD5),
-								string(network.IkeIntegritySHA1),
-								string(network.IkeIntegritySHA256),
-								string(network.IkeIntegritySHA384),
-							}, true),
-						},
-
-						"ipsec_encryption": {
-							Type:             schema.TypeString,
-							Required:         true,
-							DiffSuppressFunc: suppress.CaseDifference,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(network.IpsecEncryptionAES128),
-								string(network.IpsecEncryptionAES192),
-								string(network.IpsecEncryptionAES256),
-								string(network.IpsecEncryptionDES),
-								string(network.IpsecEncryptionDES3),
-								string(network.IpsecEncryptionGCMAES128),
-								string(network.IpsecEncryptionGCMAES192),
-								string(network.IpsecEncryptionGCMAES256),
-								string(network.IpsecEncryptionNone),
-							}, true),
-						},
-
-						"ipsec_integrity": {
-							Type:             schema.TypeString,
-							Required:         true,
-							DiffSuppressFunc: suppress.CaseDifference,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(network.IpsecIntegrityGCMAES128),
-								string(network.IpsecIntegrityGCMAES192),
-								string(network.IpsecIntegrityGCMAES256),
-								string(network.IpsecIntegrityMD5),
-								string(network.IpsecIntegritySHA1),
-								string(network.IpsecIntegritySHA256),
-							}, true),
-						},
-
-						"pfs_group": {
-							Type:             schema.TypeString,
-							Required:         true,
-							DiffSuppressFunc: suppress.CaseDifference,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(network.PfsGroupECP256),
-								string(network.PfsGroupECP384),
-								string(network.PfsGroupNone),
-								string(network.PfsGroupPFS1),
-								string(network.PfsGroupPFS2),
-								string(network.PfsGroupPFS2048),
-								string(network.PfsGroupPFS24),
-							}, true),
-						},
-
-						"sa_datasize": {
-							Type:         schema.TypeInt,
-							Optional:     true,
-							Computed:     true,
-							ValidateFunc: validation.IntAtLeast(1024),
-						},
-
-						"sa_lifetime": {
-							Type:         schema.TypeInt,
-							Optional:     true,
-							Computed:     true,
-							ValidateFunc: validation.IntAtLeast(300),
-						},
-					},
-				},
-			},
+			"ipsec_policy": ipsecPolicySchema(),
 
 			"tags": tagsSchema(),
 		},
@@ -503,6 +393,120 @@ func resourceGroupAndVirtualNetworkGatewayConnectionFromId(virtualNetworkGateway
 	return resGroup, name, nil
 }
 
+func ipsecPolicySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"dh_group": {
+					Type:             schema.TypeString,
+					Required:         true,
+					DiffSuppressFunc: suppress.CaseDifference,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(network.DHGroup1),
+						string(network.DHGroup14),
+						string(network.DHGroup2),
+						string(network.DHGroup2048),
+						string(network.DHGroup24),
+						string(network.ECP256),
+						string(network.ECP384),
+						string(network.None),
+					}, true),
+				},
+
+				"ike_encryption": {
+					Type:             schema.TypeString,
+					Required:         true,
+					DiffSuppressFunc: suppress.CaseDifference,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(network.AES128),
+						string(network.AES192),
+						string(network.AES256),
+						string(network.DES),
+						string(network.DES3),
+					}, true),
+				},
+
+				"ike_integrity": {
+					Type:             schema.TypeString,
+					Required:         true,
+					DiffSuppressFunc: suppress.CaseDifference,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(network.IkeIntegrityGCMAES128),
+						string(network.IkeIntegrityGCMAES256),
+						string(network.IkeIntegrityMD5),
+						string(network.IkeIntegritySHA1),
+						string(network.IkeIntegritySHA256),
+						string(network.IkeIntegritySHA384),
+					}, true),
+				},
+
+				"ipsec_encryption": {
+					Type:             schema.TypeString,
+					Required:         true,
+					DiffSuppressFunc: suppress.CaseDifference,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(network.IpsecEncryptionAES128),
+						string(network.IpsecEncryptionAES192),
+						string(network.IpsecEncryptionAES256),
+						string(network.IpsecEncryptionDES),
+						string(network.IpsecEncryptionDES3),
+						string(network.IpsecEncryptionGCMAES128),
+						string(network.IpsecEncryptionGCMAES192),
+						string(network.IpsecEncryptionGCMAES256),
+						string(network.IpsecEncryptionNone),
+					}, true),
+				},
+
+				"ipsec_integrity": {
+					Type:             schema.TypeString,
+					Required:         true,
+					DiffSuppressFunc: suppress.CaseDifference,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(network.IpsecIntegrityGCMAES128),
+						string(network.IpsecIntegrityGCMAES192),
+						string(network.IpsecIntegrityGCMAES256),
+						string(network.IpsecIntegrityMD5),
+						string(network.IpsecIntegritySHA1),
+						string(network.IpsecIntegritySHA256),
+					}, true),
+				},
+
+				"pfs_group": {
+					Type:             schema.TypeString,
+					Required:         true,
+					DiffSuppressFunc: suppress.CaseDifference,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(network.PfsGroupECP256),
+						string(network.PfsGroupECP384),
+						string(network.PfsGroupNone),
+						string(network.PfsGroupPFS1),
+						string(network.PfsGroupPFS2),
+						string(network.PfsGroupPFS2048),
+						string(network.PfsGroupPFS24),
+					}, true),
+				},
+
+				"sa_datasize": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntAtLeast(1024),
+				},
+
+				"sa_lifetime": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntAtLeast(300),
+				},
+			},
+		},
+	}
+}
+
 func expandArmVirtualNetworkGatewayConnectionIpsecPolicies(schemaIpsecPolicies []interface{}) *[]network.IpsecPolicy {
 	ipsecPolicies := make([]network.IpsecPolicy, 0, len(schemaIpsecPolicies))
 
@@ -3,12 +3,15 @@ package azurerm
 import (
 	"fmt"
 	"net/http"
+	"reflect"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
 func TestAccAzureRMVirtualNetwork_basic(t *testing.T) {
@@ -94,6 +97,32 @@ func TestAccAzureRMVirtualNetwork_ddosProtectionPlan(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMVirtualNetwork_subnetDualStack(t *testing.T) {
+	resourceName := "azurerm_virtual_network.test"
+	ri := tf.AccRandTimeInt()
+	config := testAccAzureRMVirtualNetwork_subnetDualStack(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualNetworkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualNetworkExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "subnet.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccAzureRMVirtualNetwork_disappears(t *testing.T) {
 	resourceName := "azurerm_virtual_network.test"
 	ri := tf.AccRandTimeInt()
@@ -282,6 +311,32 @@ resource "azurerm_virtual_network" "test" {
 `, rInt, location, rInt)
 }
 
+func testAccAzureRMVirtualNetwork_subnetDualStack(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestvirtnet%d"
+  address_space       = ["10.0.0.0/16", "ace:cab:deca::/48"]
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  subnet {
+    name           = "subnet1"
+    address_prefix = "10.0.1.0/24"
+  }
+
+  subnet {
+    name             = "subnet2"
+    address_prefixes = ["10.0.2.0/24", "ace:cab:deca:deed::/64"]
+  }
+}
+`, rInt, location, rInt)
+}
+
 func testAccAzureRMVirtualNetwork_requiresImport(rInt int, location string) string {
 	template := testAccAzureRMVirtualNetwork_basic(rInt, location)
 	return fmt.Sprintf(`
@@ -449,3 +504,68 @@ resource "azurerm_network_security_group" "test" {
 }
 `, rString, location)
 }
+
+func TestAzureRMVirtualNetwork_flattenSubnetsAddressPrefixes(t *testing.T) {
+	subnets := []network.Subnet{
+		{
+			ID:   utils.String("/subnets/single"),
+			Name: utils.String("single"),
+			SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+				AddressPrefix: utils.String("10.0.1.0/24"),
+			},
+		},
+		{
+			ID:   utils.String("/subnets/dualStack"),
+			Name: utils.String("dualStack"),
+			SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+				AddressPrefixes: &[]string{"10.0.2.0/24", "ace:cab:deca:deed::/64"},
+			},
+		},
+	}
+
+	expected := map[string]struct {
+		addressPrefix   string
+		addressPrefixes []interface{}
+	}{
+		"single": {
+			addressPrefix:   "10.0.1.0/24",
+			addressPrefixes: []interface{}{"10.0.1.0/24"},
+		},
+		"dualStack": {
+			addressPrefix:   "10.0.2.0/24",
+			addressPrefixes: []interface{}{"10.0.2.0/24", "ace:cab:deca:deed::/64"},
+		},
+	}
+
+	results := flattenVirtualNetworkSubnets(&subnets)
+	if results.Len() != len(expected) {
+		t.Fatalf("Expected %d subnets but got %d", len(expected), results.Len())
+	}
+
+	for _, raw := range results.List() {
+		subnet := raw.(map[string]interface{})
+		name := subnet["name"].(string)
+
+		v, ok := expected[name]
+		if !ok {
+			t.Fatalf("Unexpected subnet %q", name)
+		}
+
+		if subnet["address_prefix"] != v.addressPrefix {
+			t.Fatalf("Expected `address_prefix` for %q to be %q but got %q", name, v.addressPrefix, subnet["address_prefix"])
+		}
+
+		if !reflect.DeepEqual(subnet["address_prefixes"], v.addressPrefixes) {
+			t.Fatalf("Expected `address_prefixes` for %q to be %+v but got %+v", name, v.addressPrefixes, subnet["address_prefixes"])
+		}
+	}
+
+	// the hash must match the configuration, which only specifies `address_prefixes` for the dual-stack Subnet
+	config := map[string]interface{}{
+		"name":             "dualStack",
+		"address_prefixes": []interface{}{"10.0.2.0/24", "ace:cab:deca:deed::/64"},
+	}
+	if !results.Contains(config) {
+		t.Fatalf("Expected the flattened dual-stack subnet to hash the same as its configuration")
+	}
+}
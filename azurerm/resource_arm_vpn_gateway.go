@@ -0,0 +1,421 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmVpnGateway() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmVpnGatewayCreateUpdate,
+		Read:   resourceArmVpnGatewayRead,
+		Update: resourceArmVpnGatewayCreateUpdate,
+		Delete: resourceArmVpnGatewayDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"location": locationSchema(),
+
+			"virtual_hub_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"scale_unit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"bgp_settings": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"asn": {
+							Type:     schema.TypeInt,
+							Required: true,
+							ForceNew: true,
+						},
+
+						"peer_weight": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntBetween(0, 100),
+						},
+
+						"bgp_peering_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"vpn_connection": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"remote_vpn_site_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"shared_key": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"protocol": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  string(network.IKEv2),
+							ValidateFunc: validation.StringInSlice([]string{
+								string(network.IKEv1),
+								string(network.IKEv2),
+							}, false),
+						},
+
+						"routing_weight": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+
+						"bandwidth_mbps": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"bgp_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+
+						"rate_limiting_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+
+						"internet_security_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+
+						"ipsec_policy": ipsecPolicySchema(),
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceArmVpnGatewayCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vpnGatewayClient
+	ctx := meta.(*ArmClient).StopContext
+
+	log.Printf("[INFO] preparing arguments for VPN Gateway creation.")
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing VPN Gateway %q (Resource Group %q): %+v", name, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_vpn_gateway", *existing.ID)
+		}
+	}
+
+	location := azureRMNormalizeLocation(d.Get("location").(string))
+	tags := d.Get("tags").(map[string]interface{})
+
+	gateway := network.VpnGateway{
+		Location: utils.String(location),
+		Tags:     expandTags(tags),
+		VpnGatewayProperties: &network.VpnGatewayProperties{
+			VirtualHub: &network.SubResource{
+				ID: utils.String(d.Get("virtual_hub_id").(string)),
+			},
+			VpnGatewayScaleUnit: utils.Int32(int32(d.Get("scale_unit").(int))),
+			BgpSettings:         expandArmVpnGatewayBgpSettings(d.Get("bgp_settings").([]interface{})),
+			Connections:         expandArmVpnGatewayConnections(d.Get("vpn_connection").([]interface{})),
+		},
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, name, gateway)
+	if err != nil {
+		return fmt.Errorf("Error creating/updating VPN Gateway %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for creation/update of VPN Gateway %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving VPN Gateway %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read VPN Gateway %q (Resource Group %q) ID", name, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmVpnGatewayRead(d, meta)
+}
+
+func resourceArmVpnGatewayRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vpnGatewayClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["vpnGateways"]
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] VPN Gateway %q does not exist - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading VPN Gateway %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+	if location := resp.Location; location != nil {
+		d.Set("location", azureRMNormalizeLocation(*location))
+	}
+
+	if props := resp.VpnGatewayProperties; props != nil {
+		virtualHubId := ""
+		if props.VirtualHub != nil && props.VirtualHub.ID != nil {
+			virtualHubId = *props.VirtualHub.ID
+		}
+		d.Set("virtual_hub_id", virtualHubId)
+		d.Set("scale_unit", props.VpnGatewayScaleUnit)
+
+		if err := d.Set("bgp_settings", flattenArmVpnGatewayBgpSettings(props.BgpSettings)); err != nil {
+			return fmt.Errorf("Error setting `bgp_settings`: %+v", err)
+		}
+
+		// the API may not return the shared keys, in which case they're pulled from the existing state
+		if err := d.Set("vpn_connection", flattenArmVpnGatewayConnections(props.Connections, d.Get("vpn_connection").([]interface{}))); err != nil {
+			return fmt.Errorf("Error setting `vpn_connection`: %+v", err)
+		}
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmVpnGatewayDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vpnGatewayClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["vpnGateways"]
+
+	future, err := client.Delete(ctx, resourceGroup, name)
+	if err != nil {
+		// deleted outside of Terraform
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting VPN Gateway %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for the deletion of VPN Gateway %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func expandArmVpnGatewayBgpSettings(input []interface{}) *network.BgpSettings {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+	return &network.BgpSettings{
+		Asn:        utils.Int64(int64(v["asn"].(int))),
+		PeerWeight: utils.Int32(int32(v["peer_weight"].(int))),
+	}
+}
+
+func flattenArmVpnGatewayBgpSettings(input *network.BgpSettings) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	asn := 0
+	if input.Asn != nil {
+		asn = int(*input.Asn)
+	}
+
+	peerWeight := 0
+	if input.PeerWeight != nil {
+		peerWeight = int(*input.PeerWeight)
+	}
+
+	peeringAddress := ""
+	if input.BgpPeeringAddress != nil {
+		peeringAddress = *input.BgpPeeringAddress
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"asn":                 asn,
+			"peer_weight":         peerWeight,
+			"bgp_peering_address": peeringAddress,
+		},
+	}
+}
+
+func expandArmVpnGatewayConnections(input []interface{}) *[]network.VpnConnection {
+	results := make([]network.VpnConnection, 0)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+
+		props := network.VpnConnectionProperties{
+			RemoteVpnSite: &network.SubResource{
+				ID: utils.String(v["remote_vpn_site_id"].(string)),
+			},
+			VpnConnectionProtocolType: network.VirtualNetworkGatewayConnectionProtocol(v["protocol"].(string)),
+			RoutingWeight:             utils.Int32(int32(v["routing_weight"].(int))),
+			EnableBgp:                 utils.Bool(v["bgp_enabled"].(bool)),
+			EnableRateLimiting:        utils.Bool(v["rate_limiting_enabled"].(bool)),
+			EnableInternetSecurity:    utils.Bool(v["internet_security_enabled"].(bool)),
+			IpsecPolicies:             expandArmVirtualNetworkGatewayConnectionIpsecPolicies(v["ipsec_policy"].([]interface{})),
+		}
+
+		if sharedKey := v["shared_key"].(string); sharedKey != "" {
+			props.SharedKey = utils.String(sharedKey)
+		}
+
+		if bandwidth := v["bandwidth_mbps"].(int); bandwidth > 0 {
+			props.ConnectionBandwidth = utils.Int32(int32(bandwidth))
+		}
+
+		results = append(results, network.VpnConnection{
+			Name:                    utils.String(v["name"].(string)),
+			VpnConnectionProperties: &props,
+		})
+	}
+
+	return &results
+}
+
+func flattenArmVpnGatewayConnections(input *[]network.VpnConnection, existing []interface{}) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	sharedKeys := make(map[string]string)
+	for _, item := range existing {
+		if v, ok := item.(map[string]interface{}); ok {
+			sharedKeys[v["name"].(string)] = v["shared_key"].(string)
+		}
+	}
+
+	for _, connection := range *input {
+		v := make(map[string]interface{})
+
+		if connection.Name != nil {
+			v["name"] = *connection.Name
+			v["shared_key"] = sharedKeys[*connection.Name]
+		}
+
+		if props := connection.VpnConnectionProperties; props != nil {
+			if props.RemoteVpnSite != nil && props.RemoteVpnSite.ID != nil {
+				v["remote_vpn_site_id"] = *props.RemoteVpnSite.ID
+			}
+			if props.SharedKey != nil {
+				v["shared_key"] = *props.SharedKey
+			}
+			v["protocol"] = string(props.VpnConnectionProtocolType)
+			if props.RoutingWeight != nil {
+				v["routing_weight"] = int(*props.RoutingWeight)
+			}
+			if props.ConnectionBandwidth != nil {
+				v["bandwidth_mbps"] = int(*props.ConnectionBandwidth)
+			}
+			if props.EnableBgp != nil {
+				v["bgp_enabled"] = *props.EnableBgp
+			}
+			if props.EnableRateLimiting != nil {
+				v["rate_limiting_enabled"] = *props.EnableRateLimiting
+			}
+			if props.EnableInternetSecurity != nil {
+				v["internet_security_enabled"] = *props.EnableInternetSecurity
+			}
+			v["ipsec_policy"] = flattenArmVirtualNetworkGatewayConnectionIpsecPolicies(props.IpsecPolicies)
+		}
+
+		results = append(results, v)
+	}
+
+	return results
+}
@@ -0,0 +1,498 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmWebApplicationFirewallPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmWebApplicationFirewallPolicyCreateUpdate,
+		Read:   resourceArmWebApplicationFirewallPolicyRead,
+		Update: resourceArmWebApplicationFirewallPolicyCreateUpdate,
+		Delete: resourceArmWebApplicationFirewallPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"location": locationSchema(),
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"custom_rules": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"priority": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"rule_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  string(network.WebApplicationFirewallRuleTypeMatchRule),
+							ValidateFunc: validation.StringInSlice([]string{
+								string(network.WebApplicationFirewallRuleTypeMatchRule),
+							}, false),
+						},
+
+						"action": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(network.WebApplicationFirewallActionAllow),
+								string(network.WebApplicationFirewallActionBlock),
+								string(network.WebApplicationFirewallActionLog),
+							}, false),
+						},
+
+						"match_conditions": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"match_variables": {
+										Type:     schema.TypeList,
+										Required: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"variable_name": {
+													Type:     schema.TypeString,
+													Required: true,
+													ValidateFunc: validation.StringInSlice([]string{
+														string(network.PostArgs),
+														string(network.QueryString),
+														string(network.RemoteAddr),
+														string(network.RequestBody),
+														string(network.RequestCookies),
+														string(network.RequestHeaders),
+														string(network.RequestMethod),
+														string(network.RequestURI),
+													}, false),
+												},
+
+												"selector": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ValidateFunc: validate.NoEmptyStrings,
+												},
+											},
+										},
+									},
+
+									"operator": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(network.WebApplicationFirewallOperatorBeginsWith),
+											string(network.WebApplicationFirewallOperatorContains),
+											string(network.WebApplicationFirewallOperatorEndsWith),
+											string(network.WebApplicationFirewallOperatorEqual),
+											string(network.WebApplicationFirewallOperatorGreaterThan),
+											string(network.WebApplicationFirewallOperatorGreaterThanOrEqual),
+											string(network.WebApplicationFirewallOperatorIPMatch),
+											string(network.WebApplicationFirewallOperatorLessThan),
+											string(network.WebApplicationFirewallOperatorLessThanOrEqual),
+											string(network.WebApplicationFirewallOperatorRegex),
+										}, false),
+									},
+
+									"negation_condition": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+
+									"match_values": {
+										Type:     schema.TypeList,
+										Required: true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validate.NoEmptyStrings,
+										},
+									},
+
+									"transforms": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{
+												string(network.HTMLEntityDecode),
+												string(network.Lowercase),
+												string(network.RemoveNulls),
+												string(network.Trim),
+												string(network.URLDecode),
+												string(network.URLEncode),
+											}, false),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"policy_settings": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  string(network.WebApplicationFirewallModePrevention),
+							ValidateFunc: validation.StringInSlice([]string{
+								string(network.WebApplicationFirewallModeDetection),
+								string(network.WebApplicationFirewallModePrevention),
+							}, false),
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceArmWebApplicationFirewallPolicyCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).webApplicationFirewallPoliciesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing Web Application Firewall Policy %q (Resource Group %q): %s", name, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_web_application_firewall_policy", *existing.ID)
+		}
+	}
+
+	location := azureRMNormalizeLocation(d.Get("location").(string))
+	tags := d.Get("tags").(map[string]interface{})
+
+	customRules, err := expandArmWebApplicationFirewallPolicyCustomRules(d.Get("custom_rules").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	parameters := network.WebApplicationFirewallPolicy{
+		Location: utils.String(location),
+		WebApplicationFirewallPolicyPropertiesFormat: &network.WebApplicationFirewallPolicyPropertiesFormat{
+			CustomRules:    customRules,
+			PolicySettings: expandArmWebApplicationFirewallPolicyPolicySettings(d.Get("policy_settings").([]interface{})),
+		},
+		Tags: expandTags(tags),
+	}
+
+	// this is a synchronous operation, there's no future to wait on
+	if _, err := client.CreateOrUpdate(ctx, resourceGroup, name, parameters); err != nil {
+		return fmt.Errorf("Error creating/updating Web Application Firewall Policy %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Web Application Firewall Policy %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read Web Application Firewall Policy %q (Resource Group %q) ID", name, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmWebApplicationFirewallPolicyRead(d, meta)
+}
+
+func resourceArmWebApplicationFirewallPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).webApplicationFirewallPoliciesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["ApplicationGatewayWebApplicationFirewallPolicies"]
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] Web Application Firewall Policy %q does not exist - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading Web Application Firewall Policy %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+	if location := resp.Location; location != nil {
+		d.Set("location", azureRMNormalizeLocation(*location))
+	}
+
+	if props := resp.WebApplicationFirewallPolicyPropertiesFormat; props != nil {
+		if err := d.Set("custom_rules", flattenArmWebApplicationFirewallPolicyCustomRules(props.CustomRules)); err != nil {
+			return fmt.Errorf("Error setting `custom_rules`: %+v", err)
+		}
+
+		if err := d.Set("policy_settings", flattenArmWebApplicationFirewallPolicyPolicySettings(props.PolicySettings)); err != nil {
+			return fmt.Errorf("Error setting `policy_settings`: %+v", err)
+		}
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmWebApplicationFirewallPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).webApplicationFirewallPoliciesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["ApplicationGatewayWebApplicationFirewallPolicies"]
+
+	log.Printf("[DEBUG] Deleting Web Application Firewall Policy %q (Resource Group %q)", name, resourceGroup)
+
+	future, err := client.Delete(ctx, resourceGroup, name)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Web Application Firewall Policy %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for deletion of Web Application Firewall Policy %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func expandArmWebApplicationFirewallPolicyCustomRules(input []interface{}) (*[]network.WebApplicationFirewallCustomRule, error) {
+	results := make([]network.WebApplicationFirewallCustomRule, 0)
+	priorities := make(map[int]bool)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+
+		priority := v["priority"].(int)
+		if priorities[priority] {
+			return nil, fmt.Errorf("Error expanding `custom_rules`: the priority %d is used by more than one rule", priority)
+		}
+		priorities[priority] = true
+
+		result := network.WebApplicationFirewallCustomRule{
+			Priority:        utils.Int32(int32(priority)),
+			RuleType:        network.WebApplicationFirewallRuleType(v["rule_type"].(string)),
+			Action:          network.WebApplicationFirewallAction(v["action"].(string)),
+			MatchConditions: expandArmWebApplicationFirewallPolicyMatchConditions(v["match_conditions"].([]interface{})),
+		}
+
+		if name := v["name"].(string); name != "" {
+			result.Name = utils.String(name)
+		}
+
+		results = append(results, result)
+	}
+
+	return &results, nil
+}
+
+func expandArmWebApplicationFirewallPolicyMatchConditions(input []interface{}) *[]network.MatchCondition {
+	results := make([]network.MatchCondition, 0)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+
+		transforms := make([]network.WebApplicationFirewallTransform, 0)
+		for _, transform := range v["transforms"].(*schema.Set).List() {
+			transforms = append(transforms, network.WebApplicationFirewallTransform(transform.(string)))
+		}
+
+		results = append(results, network.MatchCondition{
+			MatchVariables:   expandArmWebApplicationFirewallPolicyMatchVariables(v["match_variables"].([]interface{})),
+			Operator:         network.WebApplicationFirewallOperator(v["operator"].(string)),
+			NegationConditon: utils.Bool(v["negation_condition"].(bool)),
+			MatchValues:      utils.ExpandStringArray(v["match_values"].([]interface{})),
+			Transforms:       &transforms,
+		})
+	}
+
+	return &results
+}
+
+func expandArmWebApplicationFirewallPolicyMatchVariables(input []interface{}) *[]network.MatchVariable {
+	results := make([]network.MatchVariable, 0)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+
+		result := network.MatchVariable{
+			VariableName: network.WebApplicationFirewallMatchVariable(v["variable_name"].(string)),
+		}
+
+		if selector := v["selector"].(string); selector != "" {
+			result.Selector = utils.String(selector)
+		}
+
+		results = append(results, result)
+	}
+
+	return &results
+}
+
+func expandArmWebApplicationFirewallPolicyPolicySettings(input []interface{}) *network.PolicySettings {
+	enabledState := network.WebApplicationFirewallEnabledStateEnabled
+	mode := network.WebApplicationFirewallModePrevention
+
+	if len(input) > 0 && input[0] != nil {
+		v := input[0].(map[string]interface{})
+
+		if !v["enabled"].(bool) {
+			enabledState = network.WebApplicationFirewallEnabledStateDisabled
+		}
+		mode = network.WebApplicationFirewallMode(v["mode"].(string))
+	}
+
+	return &network.PolicySettings{
+		EnabledState: enabledState,
+		Mode:         mode,
+	}
+}
+
+func flattenArmWebApplicationFirewallPolicyCustomRules(input *[]network.WebApplicationFirewallCustomRule) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		v := make(map[string]interface{})
+
+		if name := item.Name; name != nil {
+			v["name"] = *name
+		}
+		if priority := item.Priority; priority != nil {
+			v["priority"] = int(*priority)
+		}
+		v["rule_type"] = string(item.RuleType)
+		v["action"] = string(item.Action)
+		v["match_conditions"] = flattenArmWebApplicationFirewallPolicyMatchConditions(item.MatchConditions)
+
+		results = append(results, v)
+	}
+
+	return results
+}
+
+func flattenArmWebApplicationFirewallPolicyMatchConditions(input *[]network.MatchCondition) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		v := make(map[string]interface{})
+
+		v["operator"] = string(item.Operator)
+		if negate := item.NegationConditon; negate != nil {
+			v["negation_condition"] = *negate
+		}
+		v["match_values"] = utils.FlattenStringArray(item.MatchValues)
+		v["match_variables"] = flattenArmWebApplicationFirewallPolicyMatchVariables(item.MatchVariables)
+
+		transforms := make([]interface{}, 0)
+		if item.Transforms != nil {
+			for _, transform := range *item.Transforms {
+				transforms = append(transforms, string(transform))
+			}
+		}
+		v["transforms"] = schema.NewSet(schema.HashString, transforms)
+
+		results = append(results, v)
+	}
+
+	return results
+}
+
+func flattenArmWebApplicationFirewallPolicyMatchVariables(input *[]network.MatchVariable) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		v := make(map[string]interface{})
+
+		v["variable_name"] = string(item.VariableName)
+		if selector := item.Selector; selector != nil {
+			v["selector"] = *selector
+		}
+
+		results = append(results, v)
+	}
+
+	return results
+}
+
+func flattenArmWebApplicationFirewallPolicyPolicySettings(input *network.PolicySettings) []interface{} {
+	if input == nil {
+		return make([]interface{}, 0)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"enabled": input.EnabledState == network.WebApplicationFirewallEnabledStateEnabled,
+			"mode":    string(input.Mode),
+		},
+	}
+}
@@ -0,0 +1,292 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAccAzureRMWebApplicationFirewallPolicy_basic(t *testing.T) {
+	resourceName := "azurerm_web_application_firewall_policy.test"
+	ri := tf.AccRandTimeInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMWebApplicationFirewallPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMWebApplicationFirewallPolicy_basic(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMWebApplicationFirewallPolicyExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "policy_settings.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "policy_settings.0.enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "policy_settings.0.mode", "Prevention"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMWebApplicationFirewallPolicy_requiresImport(t *testing.T) {
+	if !requireResourcesToBeImported {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azurerm_web_application_firewall_policy.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMWebApplicationFirewallPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMWebApplicationFirewallPolicy_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMWebApplicationFirewallPolicyExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccAzureRMWebApplicationFirewallPolicy_requiresImport(ri, location),
+				ExpectError: testRequiresImportError("azurerm_web_application_firewall_policy"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMWebApplicationFirewallPolicy_complete(t *testing.T) {
+	resourceName := "azurerm_web_application_firewall_policy.test"
+	ri := tf.AccRandTimeInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMWebApplicationFirewallPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMWebApplicationFirewallPolicy_complete(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMWebApplicationFirewallPolicyExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.0.name", "Rule1"),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.0.priority", "1"),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.0.action", "Block"),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.0.match_conditions.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.0.match_conditions.0.operator", "IPMatch"),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.0.match_conditions.0.match_values.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.1.name", "Rule2"),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.1.match_conditions.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.1.match_conditions.1.match_variables.0.variable_name", "RequestHeaders"),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.1.match_conditions.1.match_variables.0.selector", "UserAgent"),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.1.match_conditions.1.transforms.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "policy_settings.0.enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "policy_settings.0.mode", "Detection"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMWebApplicationFirewallPolicy_update(t *testing.T) {
+	resourceName := "azurerm_web_application_firewall_policy.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMWebApplicationFirewallPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMWebApplicationFirewallPolicy_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMWebApplicationFirewallPolicyExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.#", "0"),
+				),
+			},
+			{
+				Config: testAccAzureRMWebApplicationFirewallPolicy_complete(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMWebApplicationFirewallPolicyExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "policy_settings.0.mode", "Detection"),
+				),
+			},
+			{
+				Config: testAccAzureRMWebApplicationFirewallPolicy_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMWebApplicationFirewallPolicyExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "custom_rules.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMWebApplicationFirewallPolicyExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Web Application Firewall Policy not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).webApplicationFirewallPoliciesClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Web Application Firewall Policy %q (Resource Group %q) does not exist", name, resourceGroup)
+			}
+			return fmt.Errorf("Bad: Get on webApplicationFirewallPoliciesClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMWebApplicationFirewallPolicyDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).webApplicationFirewallPoliciesClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_web_application_firewall_policy" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return nil
+			}
+			return err
+		}
+
+		return fmt.Errorf("Web Application Firewall Policy still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccAzureRMWebApplicationFirewallPolicy_basic(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_web_application_firewall_policy" "test" {
+  name                = "acctestwafpolicy-%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMWebApplicationFirewallPolicy_requiresImport(rInt int, location string) string {
+	template := testAccAzureRMWebApplicationFirewallPolicy_basic(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_web_application_firewall_policy" "import" {
+  name                = "${azurerm_web_application_firewall_policy.test.name}"
+  resource_group_name = "${azurerm_web_application_firewall_policy.test.resource_group_name}"
+  location            = "${azurerm_web_application_firewall_policy.test.location}"
+}
+`, template)
+}
+
+func testAccAzureRMWebApplicationFirewallPolicy_complete(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_web_application_firewall_policy" "test" {
+  name                = "acctestwafpolicy-%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+
+  custom_rules {
+    name      = "Rule1"
+    priority  = 1
+    rule_type = "MatchRule"
+    action    = "Block"
+
+    match_conditions {
+      match_variables {
+        variable_name = "RemoteAddr"
+      }
+
+      operator           = "IPMatch"
+      negation_condition = false
+      match_values       = ["192.168.1.0/24", "10.0.0.0/24"]
+    }
+  }
+
+  custom_rules {
+    name      = "Rule2"
+    priority  = 2
+    rule_type = "MatchRule"
+    action    = "Block"
+
+    match_conditions {
+      match_variables {
+        variable_name = "RemoteAddr"
+      }
+
+      operator           = "IPMatch"
+      negation_condition = false
+      match_values       = ["192.168.1.0/24"]
+    }
+
+    match_conditions {
+      match_variables {
+        variable_name = "RequestHeaders"
+        selector      = "UserAgent"
+      }
+
+      operator           = "Contains"
+      negation_condition = false
+      match_values       = ["Windows"]
+      transforms         = ["Lowercase"]
+    }
+  }
+
+  policy_settings {
+    enabled = true
+    mode    = "Detection"
+  }
+
+  tags = {
+    environment = "Production"
+  }
+}
+`, rInt, location, rInt)
+}